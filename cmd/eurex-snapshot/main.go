@@ -0,0 +1,85 @@
+/*
+	Command eurex-snapshot generates snapshot of ECB rates which is embedded into offline package.
+
+	By default, complete history of rates is downloaded from ECB. Use -in flag to generate snapshot from already
+	downloaded https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml file instead:
+		go run ./cmd/eurex-snapshot -in eurofxref-hist.xml -out offline/snapshot.csv.gz
+	Files with .zip and .csv extension (eg. eurofxref-hist.zip) are read as ZIP archive and CSV file published by ECB.
+*/
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/filiptubic/eurex/ecb"
+	"github.com/filiptubic/eurex/offline"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	in := flag.String("in", "", "path of ECB XML, ZIP or CSV file with rates, rates are downloaded from ECB when empty")
+	out := flag.String("out", "snapshot.csv.gz", "path of generated snapshot")
+	flag.Parse()
+
+	logger := log.New()
+	data, err := readRates(*in, logger)
+	if err != nil {
+		logger.Fatalf("failed to read rates: %v", err)
+	}
+	if len(data.Data) == 0 {
+		logger.Fatal("no rates found")
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		logger.Fatalf("failed to create snapshot: %v", err)
+	}
+	if err := offline.WriteSnapshot(f, data); err != nil {
+		f.Close()
+		logger.Fatalf("failed to write snapshot: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		logger.Fatalf("failed to write snapshot: %v", err)
+	}
+	logger.Infof("snapshot with rates for %d dates written to %s", len(data.Data), *out)
+}
+
+// readRates reads rates from ECB XML, ZIP or CSV file at path, or downloads complete history from ECB when path is empty.
+func readRates(path string, logger *log.Logger) (*ecb.ECBResponseData, error) {
+	if path == "" {
+		options := ecb.NewECBOptions(3, time.Second*3).WithFeedFormat(ecb.FeedFormatZIP)
+		client := ecb.NewECBClient("https", "www.ecb.europa.eu", options, logger)
+		return client.GetFeed(ecb.FeedHistory)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var decode func(r io.Reader, fn func(day ecb.DataXML) error) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".zip":
+		decode = ecb.DecodeZIPFeed
+	case ".csv":
+		decode = ecb.DecodeCSVFeed
+	default:
+		return ecb.ParseFeed(f)
+	}
+
+	data := &ecb.ECBResponseData{}
+	err = decode(f, func(day ecb.DataXML) error {
+		data.Data = append(data.Data, day)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
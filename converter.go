@@ -5,6 +5,8 @@
 package eurex
 
 import (
+	"context"
+	"math/big"
 	"time"
 
 	"github.com/filiptubic/eurex/currency"
@@ -27,3 +29,34 @@ var (
 type Converter interface {
 	Convert(date time.Time, value float64, from, to currency.Currency) (converted float64, err error)
 }
+
+// ContextConverter defines converter API which supports cancellation and deadlines of conversion using context.
+type ContextConverter interface {
+	Converter
+	ConvertContext(ctx context.Context, date time.Time, value float64, from, to currency.Currency) (converted float64, err error)
+}
+
+// ConversionResult holds converted value together with provenance of applied rates.
+type ConversionResult = ecb.ConversionResult
+
+// ResultConverter defines converter API which returns converted value together with applied rates, their date and source.
+type ResultConverter interface {
+	ConvertResultContext(ctx context.Context, date time.Time, value float64, from, to currency.Currency) (result ConversionResult, err error)
+}
+
+// ExactConverter defines converter API which converts values using exact decimal arithmetic.
+type ExactConverter interface {
+	ConvertExactContext(ctx context.Context, date time.Time, value *big.Rat, from, to currency.Currency) (converted *big.Rat, err error)
+}
+
+// MoneyConverter defines converter API which converts Money to another currency.
+type MoneyConverter interface {
+	ConvertMoneyContext(ctx context.Context, date time.Time, money currency.Money, to currency.Currency) (converted currency.Money, err error)
+}
+
+var (
+	_ ContextConverter = (*ecb.ECBConverter)(nil)
+	_ ResultConverter  = (*ecb.ECBConverter)(nil)
+	_ ExactConverter   = (*ecb.ECBConverter)(nil)
+	_ MoneyConverter   = (*ecb.ECBConverter)(nil)
+)
@@ -1,5 +1,7 @@
 /*
 	Use this package to access complete list of currencies. Various converters use various subsets of this list.
+	Currencies are registered according to ISO 4217, including historic currencies (eg. the ones replaced by EUR),
+	and their metadata (numeric code, name, minor units and symbol) is available using Lookup and LookupNumeric.
 */
 package currency
 
@@ -8,73 +10,237 @@ package currency
 type Currency string
 
 var (
+	ADP = Currency("ADP")
+	AED = Currency("AED")
+	AFN = Currency("AFN")
+	ALL = Currency("ALL")
+	AMD = Currency("AMD")
+	ANG = Currency("ANG")
+	AOA = Currency("AOA")
+	ARS = Currency("ARS")
+	ATS = Currency("ATS")
 	AUD = Currency("AUD")
+	AWG = Currency("AWG")
+	AZM = Currency("AZM")
+	AZN = Currency("AZN")
+	BAM = Currency("BAM")
+	BBD = Currency("BBD")
+	BDT = Currency("BDT")
+	BEF = Currency("BEF")
+	BGL = Currency("BGL")
 	BGN = Currency("BGN")
+	BHD = Currency("BHD")
+	BIF = Currency("BIF")
+	BMD = Currency("BMD")
+	BND = Currency("BND")
+	BOB = Currency("BOB")
+	BOV = Currency("BOV")
 	BRL = Currency("BRL")
+	BSD = Currency("BSD")
+	BTN = Currency("BTN")
+	BWP = Currency("BWP")
+	BYN = Currency("BYN")
+	BYR = Currency("BYR")
+	BZD = Currency("BZD")
 	CAD = Currency("CAD")
+	CDF = Currency("CDF")
+	CHE = Currency("CHE")
 	CHF = Currency("CHF")
+	CHW = Currency("CHW")
+	CLF = Currency("CLF")
+	CLP = Currency("CLP")
 	CNY = Currency("CNY")
+	COP = Currency("COP")
+	COU = Currency("COU")
+	CRC = Currency("CRC")
+	CSD = Currency("CSD")
+	CUC = Currency("CUC")
+	CUP = Currency("CUP")
+	CVE = Currency("CVE")
+	CYP = Currency("CYP")
 	CZK = Currency("CZK")
+	DEM = Currency("DEM")
+	DJF = Currency("DJF")
 	DKK = Currency("DKK")
+	DOP = Currency("DOP")
+	DZD = Currency("DZD")
+	EEK = Currency("EEK")
+	EGP = Currency("EGP")
+	ERN = Currency("ERN")
+	ESP = Currency("ESP")
+	ETB = Currency("ETB")
 	EUR = Currency("EUR")
+	FIM = Currency("FIM")
+	FJD = Currency("FJD")
+	FKP = Currency("FKP")
+	FRF = Currency("FRF")
 	GBP = Currency("GBP")
+	GEL = Currency("GEL")
+	GHC = Currency("GHC")
+	GHS = Currency("GHS")
+	GIP = Currency("GIP")
+	GMD = Currency("GMD")
+	GNF = Currency("GNF")
+	GRD = Currency("GRD")
+	GTQ = Currency("GTQ")
+	GYD = Currency("GYD")
 	HKD = Currency("HKD")
+	HNL = Currency("HNL")
 	HRK = Currency("HRK")
-	ISK = Currency("ISK")
+	HTG = Currency("HTG")
 	HUF = Currency("HUF")
 	IDR = Currency("IDR")
+	IEP = Currency("IEP")
 	ILS = Currency("ILS")
 	INR = Currency("INR")
+	IQD = Currency("IQD")
+	IRR = Currency("IRR")
+	ISK = Currency("ISK")
+	ITL = Currency("ITL")
+	JMD = Currency("JMD")
+	JOD = Currency("JOD")
 	JPY = Currency("JPY")
+	KES = Currency("KES")
+	KGS = Currency("KGS")
+	KHR = Currency("KHR")
+	KMF = Currency("KMF")
+	KPW = Currency("KPW")
 	KRW = Currency("KRW")
+	KWD = Currency("KWD")
+	KYD = Currency("KYD")
+	KZT = Currency("KZT")
+	LAK = Currency("LAK")
+	LBP = Currency("LBP")
+	LKR = Currency("LKR")
+	LRD = Currency("LRD")
+	LSL = Currency("LSL")
+	LTL = Currency("LTL")
+	LUF = Currency("LUF")
+	LVL = Currency("LVL")
+	LYD = Currency("LYD")
+	MAD = Currency("MAD")
+	MDL = Currency("MDL")
+	MGA = Currency("MGA")
+	MKD = Currency("MKD")
+	MMK = Currency("MMK")
+	MNT = Currency("MNT")
+	MOP = Currency("MOP")
+	MRO = Currency("MRO")
+	MRU = Currency("MRU")
+	MTL = Currency("MTL")
+	MUR = Currency("MUR")
+	MVR = Currency("MVR")
+	MWK = Currency("MWK")
 	MXN = Currency("MXN")
+	MXV = Currency("MXV")
 	MYR = Currency("MYR")
+	MZM = Currency("MZM")
+	MZN = Currency("MZN")
+	NAD = Currency("NAD")
+	NGN = Currency("NGN")
+	NIO = Currency("NIO")
+	NLG = Currency("NLG")
 	NOK = Currency("NOK")
+	NPR = Currency("NPR")
 	NZD = Currency("NZD")
+	OMR = Currency("OMR")
+	PAB = Currency("PAB")
+	PEN = Currency("PEN")
+	PGK = Currency("PGK")
 	PHP = Currency("PHP")
+	PKR = Currency("PKR")
 	PLN = Currency("PLN")
+	PTE = Currency("PTE")
+	PYG = Currency("PYG")
+	QAR = Currency("QAR")
+	ROL = Currency("ROL")
 	RON = Currency("RON")
+	RSD = Currency("RSD")
 	RUB = Currency("RUB")
+	RUR = Currency("RUR")
+	RWF = Currency("RWF")
+	SAR = Currency("SAR")
+	SBD = Currency("SBD")
+	SCR = Currency("SCR")
+	SDD = Currency("SDD")
+	SDG = Currency("SDG")
 	SEK = Currency("SEK")
 	SGD = Currency("SGD")
+	SHP = Currency("SHP")
+	SIT = Currency("SIT")
+	SKK = Currency("SKK")
+	SLE = Currency("SLE")
+	SLL = Currency("SLL")
+	SOS = Currency("SOS")
+	SRD = Currency("SRD")
+	SRG = Currency("SRG")
+	SSP = Currency("SSP")
+	STD = Currency("STD")
+	STN = Currency("STN")
+	SVC = Currency("SVC")
+	SYP = Currency("SYP")
+	SZL = Currency("SZL")
 	THB = Currency("THB")
+	TJS = Currency("TJS")
+	TMM = Currency("TMM")
+	TMT = Currency("TMT")
+	TND = Currency("TND")
+	TOP = Currency("TOP")
+	TRL = Currency("TRL")
 	TRY = Currency("TRY")
+	TTD = Currency("TTD")
+	TWD = Currency("TWD")
+	TZS = Currency("TZS")
+	UAH = Currency("UAH")
+	UGX = Currency("UGX")
 	USD = Currency("USD")
+	USN = Currency("USN")
+	UYI = Currency("UYI")
+	UYU = Currency("UYU")
+	UYW = Currency("UYW")
+	UZS = Currency("UZS")
+	VEB = Currency("VEB")
+	VED = Currency("VED")
+	VEF = Currency("VEF")
+	VES = Currency("VES")
+	VND = Currency("VND")
+	VUV = Currency("VUV")
+	WST = Currency("WST")
+	XAF = Currency("XAF")
+	XAG = Currency("XAG")
+	XAU = Currency("XAU")
+	XBA = Currency("XBA")
+	XBB = Currency("XBB")
+	XBC = Currency("XBC")
+	XBD = Currency("XBD")
+	XCD = Currency("XCD")
+	XCG = Currency("XCG")
+	XDR = Currency("XDR")
+	XEU = Currency("XEU")
+	XOF = Currency("XOF")
+	XPD = Currency("XPD")
+	XPF = Currency("XPF")
+	XPT = Currency("XPT")
+	XSU = Currency("XSU")
+	XTS = Currency("XTS")
+	XUA = Currency("XUA")
+	XXX = Currency("XXX")
+	YER = Currency("YER")
 	ZAR = Currency("ZAR")
+	ZMK = Currency("ZMK")
+	ZMW = Currency("ZMW")
+	ZWD = Currency("ZWD")
+	ZWG = Currency("ZWG")
+	ZWL = Currency("ZWL")
 
-	Currencies = map[string]Currency{
-		"AUD": AUD,
-		"BGN": BGN,
-		"BRL": BRL,
-		"CAD": CAD,
-		"CHF": CHF,
-		"CNY": CNY,
-		"CZK": CZK,
-		"DKK": DKK,
-		"EUR": EUR,
-		"GBP": GBP,
-		"HKD": HKD,
-		"HRK": HRK,
-		"ISK": ISK,
-		"HUF": HUF,
-		"IDR": IDR,
-		"ILS": ILS,
-		"INR": INR,
-		"JPY": JPY,
-		"KRW": KRW,
-		"MXN": MXN,
-		"MYR": MYR,
-		"NOK": NOK,
-		"NZD": NZD,
-		"PHP": PHP,
-		"PLN": PLN,
-		"RON": RON,
-		"RUB": RUB,
-		"SEK": SEK,
-		"SGD": SGD,
-		"THB": THB,
-		"TRY": TRY,
-		"USD": USD,
-		"ZAR": ZAR,
-	}
+	// Currencies holds all ISO 4217 currencies, including historic ones, by their alphabetic code.
+	Currencies = currencies()
 )
+
+func currencies() map[string]Currency {
+	currencies := make(map[string]Currency, len(registry))
+	for _, info := range registry {
+		currencies[string(info.Code)] = info.Code
+	}
+	return currencies
+}
@@ -0,0 +1,30 @@
+package currency
+
+import "fmt"
+
+// CurrencyMismatch is used when Money in different currencies are combined (eg. EUR is added to USD).
+type CurrencyMismatch struct {
+	first, second Currency
+}
+
+func (e CurrencyMismatch) Error() string {
+	return fmt.Sprintf("currency mismatch: %s and %s", e.first, e.second)
+}
+
+// InvalidAmount is used when money amount can't be parsed.
+type InvalidAmount struct {
+	amount string
+}
+
+func (e InvalidAmount) Error() string {
+	return fmt.Sprintf("invalid amount: %s", e.amount)
+}
+
+// InvalidAllocation is used when Money can't be allocated using given ratios.
+type InvalidAllocation struct {
+	msg string
+}
+
+func (e InvalidAllocation) Error() string {
+	return fmt.Sprintf("invalid allocation: %s", e.msg)
+}
@@ -0,0 +1,21 @@
+package currency
+
+import "fmt"
+
+func ExampleCurrencyMismatch_Error() {
+	fmt.Println(CurrencyMismatch{first: EUR, second: USD}.Error())
+	// Output:
+	// currency mismatch: EUR and USD
+}
+
+func ExampleInvalidAmount_Error() {
+	fmt.Println(InvalidAmount{amount: "1,5"}.Error())
+	// Output:
+	// invalid amount: 1,5
+}
+
+func ExampleInvalidAllocation_Error() {
+	fmt.Println(InvalidAllocation{msg: "no ratios"}.Error())
+	// Output:
+	// invalid allocation: no ratios
+}
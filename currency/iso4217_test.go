@@ -0,0 +1,88 @@
+package currency
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	for i, info := range registry {
+		if len(info.Code) != 3 {
+			t.Errorf("invalid code: %s", info.Code)
+		}
+		if i > 0 && registry[i-1].Code >= info.Code {
+			t.Errorf("registry not sorted by code at %s", info.Code)
+		}
+		if info.Numeric <= 0 || info.Numeric > 999 {
+			t.Errorf("invalid numeric code of %s: %d", info.Code, info.Numeric)
+		}
+		if info.Name == "" {
+			t.Errorf("missing name of %s", info.Code)
+		}
+		if info.MinorUnits < NoMinorUnits || info.MinorUnits > 4 {
+			t.Errorf("invalid minor units of %s: %d", info.Code, info.MinorUnits)
+		}
+		if _, ok := Currencies[string(info.Code)]; !ok {
+			t.Errorf("%s missing in Currencies", info.Code)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tt := []struct {
+		code     string
+		expected Info
+		ok       bool
+	}{
+		{code: "EUR", expected: Info{Code: EUR, Numeric: 978, Name: "Euro", MinorUnits: 2, Symbol: "€"}, ok: true},
+		{code: "jpy", expected: Info{Code: JPY, Numeric: 392, Name: "Yen", MinorUnits: 0, Symbol: "¥"}, ok: true},
+		{code: "BHD", expected: Info{Code: BHD, Numeric: 48, Name: "Bahraini Dinar", MinorUnits: 3}, ok: true},
+		{code: "DEM", expected: Info{Code: DEM, Numeric: 276, Name: "Deutsche Mark", MinorUnits: 2, Historic: true}, ok: true},
+		{code: "XAU", expected: Info{Code: XAU, Numeric: 959, Name: "Gold", MinorUnits: NoMinorUnits}, ok: true},
+		{code: "UNKNOWN"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.code, func(t *testing.T) {
+			info, ok := Lookup(test.code)
+			if ok != test.ok || info != test.expected {
+				t.Errorf("expecting %+v, got: %+v", test.expected, info)
+			}
+		})
+	}
+}
+
+func TestLookupNumeric(t *testing.T) {
+	tt := []struct {
+		numeric  int
+		expected Currency
+		ok       bool
+	}{
+		{numeric: 978, expected: EUR, ok: true},
+		{numeric: 840, expected: USD, ok: true},
+		{numeric: 8, expected: ALL, ok: true},
+		{numeric: 191, expected: HRK, ok: true},
+		{numeric: 532, expected: XCG, ok: true},
+		{numeric: 1},
+	}
+
+	for _, test := range tt {
+		t.Run(fmt.Sprint(test.numeric), func(t *testing.T) {
+			info, ok := LookupNumeric(test.numeric)
+			if ok != test.ok || info.Code != test.expected {
+				t.Errorf("expecting %s, got: %s", test.expected, info.Code)
+			}
+		})
+	}
+}
+
+func ExampleLookup() {
+	info, ok := Lookup("CHF")
+	fmt.Println(info.Numeric, info.Name, info.MinorUnits, ok)
+	// Output: 756 Swiss Franc 2 true
+}
+
+func ExampleCurrency_Symbol() {
+	fmt.Println(EUR.Symbol(), USD.Symbol(), CHF.Symbol())
+	// Output: € $ CHF
+}
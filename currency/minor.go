@@ -0,0 +1,21 @@
+package currency
+
+import "math/big"
+
+// DefaultMinorUnits is number of decimal places of minor unit (eg. cent) used by most currencies.
+const DefaultMinorUnits = 2
+
+// MinorUnits returns number of decimal places of currency minor unit as defined by ISO 4217,
+// eg. 2 for EUR (cents) and 0 for JPY. Amounts in currency are booked with this precision.
+// DefaultMinorUnits is returned for unknown currencies and currencies without minor units (eg. XAU gold).
+func (c Currency) MinorUnits() int {
+	if info, ok := byCode[c]; ok && info.MinorUnits != NoMinorUnits {
+		return info.MinorUnits
+	}
+	return DefaultMinorUnits
+}
+
+// Round rounds value to minor units of currency using mode.
+func (c Currency) Round(value *big.Rat, mode RoundingMode) *big.Rat {
+	return Round(value, c.MinorUnits(), mode)
+}
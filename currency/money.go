@@ -0,0 +1,152 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Money is amount in certain currency. Arithmetic on Money refuses to mix currencies, so eg. EUR can't be added to USD
+// by accident. Money is immutable, all operations return new value. Zero value is zero amount without currency.
+type Money struct {
+	amount   *big.Rat
+	currency Currency
+}
+
+// NewMoney creates Money holding copy of amount in currency.
+func NewMoney(amount *big.Rat, currency Currency) Money {
+	return Money{amount: new(big.Rat).Set(amount), currency: currency}
+}
+
+// ParseMoney creates Money from decimal amount (eg. "10.25") in currency.
+func ParseMoney(amount string, currency Currency) (Money, error) {
+	parsed, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return Money{}, InvalidAmount{amount}
+	}
+	return Money{amount: parsed, currency: currency}, nil
+}
+
+// Amount returns copy of money amount.
+func (m Money) Amount() *big.Rat {
+	return new(big.Rat).Set(m.rat())
+}
+
+// Currency returns currency of money.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Float64 returns float64 nearest to money amount.
+func (m Money) Float64() float64 {
+	f, _ := m.rat().Float64()
+	return f
+}
+
+// IsZero checks whether money amount is zero.
+func (m Money) IsZero() bool {
+	return m.rat().Sign() == 0
+}
+
+// Cmp compares money with other money in the same currency and returns -1, 0 or +1 when money is less than,
+// equal to or greater than other.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.same(other); err != nil {
+		return 0, err
+	}
+	return m.rat().Cmp(other.rat()), nil
+}
+
+// Add returns sum of money and other money in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.same(other); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: new(big.Rat).Add(m.rat(), other.rat()), currency: m.currency}, nil
+}
+
+// Sub returns difference of money and other money in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.same(other); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: new(big.Rat).Sub(m.rat(), other.rat()), currency: m.currency}, nil
+}
+
+// Mul returns money multiplied by factor (eg. quantity or tax rate).
+func (m Money) Mul(factor *big.Rat) Money {
+	return Money{amount: new(big.Rat).Mul(m.rat(), factor), currency: m.currency}
+}
+
+// Round returns money rounded to minor units of its currency using mode.
+func (m Money) Round(mode RoundingMode) Money {
+	return Money{amount: m.currency.Round(m.rat(), mode), currency: m.currency}
+}
+
+// Allocate splits money into parts proportional to ratios, eg. Allocate(1, 1, 1) splits 10.00 EUR into
+// 3.34, 3.33 and 3.33 EUR. Parts are whole minor units of currency and their sum is always equal to money,
+// so money must be whole minor units as well. Remaining minor units are given to the first parts.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, InvalidAllocation{"no ratios"}
+	}
+	total := int64(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, InvalidAllocation{fmt.Sprintf("negative ratio: %d", ratio)}
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, InvalidAllocation{"sum of ratios is zero"}
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(m.currency.MinorUnits())), nil)
+	units := new(big.Rat).Mul(m.rat(), new(big.Rat).SetInt(scale))
+	if !units.IsInt() {
+		return nil, InvalidAllocation{fmt.Sprintf("%v is not whole minor units", m)}
+	}
+
+	shares := make([]*big.Int, len(ratios))
+	remainder := new(big.Int).Set(units.Num())
+	for i, ratio := range ratios {
+		// quotient is truncated towards zero, so remainder has the same sign as money
+		shares[i] = new(big.Int).Mul(units.Num(), big.NewInt(int64(ratio)))
+		shares[i].Quo(shares[i], big.NewInt(total))
+		remainder.Sub(remainder, shares[i])
+	}
+	unit := big.NewInt(int64(remainder.Sign()))
+	for i := 0; remainder.Sign() != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Add(shares[i], unit)
+		remainder.Sub(remainder, unit)
+	}
+
+	parts := make([]Money, len(ratios))
+	for i, share := range shares {
+		parts[i] = Money{amount: new(big.Rat).SetFrac(share, scale), currency: m.currency}
+	}
+	return parts, nil
+}
+
+// String formats money with minor units of its currency, eg. "10.25 EUR".
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.rat().FloatString(m.currency.MinorUnits()), m.currency)
+}
+
+// rat returns money amount, treating missing amount of zero value as zero.
+func (m Money) rat() *big.Rat {
+	if m.amount == nil {
+		return new(big.Rat)
+	}
+	return m.amount
+}
+
+// same checks that other money is in the same currency as money.
+func (m Money) same(other Money) error {
+	if m.currency != other.currency {
+		return CurrencyMismatch{m.currency, other.currency}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func mustParseMoney(amount string, currency Currency) Money {
+	money, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
+func TestMoney_arithmetic(t *testing.T) {
+	tt := []struct {
+		name     string
+		op       func() (Money, error)
+		expected Money
+		err      error
+	}{
+		{
+			name:     "add",
+			op:       func() (Money, error) { return mustParseMoney("0.1", EUR).Add(mustParseMoney("0.2", EUR)) },
+			expected: mustParseMoney("0.3", EUR),
+		},
+		{
+			name: "add mixed currencies",
+			op:   func() (Money, error) { return mustParseMoney("1", EUR).Add(mustParseMoney("1", USD)) },
+			err:  CurrencyMismatch{EUR, USD},
+		},
+		{
+			name:     "sub",
+			op:       func() (Money, error) { return mustParseMoney("1", USD).Sub(mustParseMoney("2.5", USD)) },
+			expected: mustParseMoney("-1.5", USD),
+		},
+		{
+			name: "sub mixed currencies",
+			op:   func() (Money, error) { return mustParseMoney("1", USD).Sub(mustParseMoney("1", JPY)) },
+			err:  CurrencyMismatch{USD, JPY},
+		},
+		{
+			name:     "mul",
+			op:       func() (Money, error) { return mustParseMoney("19.99", EUR).Mul(big.NewRat(3, 1)), nil },
+			expected: mustParseMoney("59.97", EUR),
+		},
+		{
+			name:     "zero value",
+			op:       func() (Money, error) { return Money{currency: EUR}.Add(mustParseMoney("1", EUR)) },
+			expected: mustParseMoney("1", EUR),
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.op()
+			if err != test.err {
+				t.Fatalf("expecting error %v, got: %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if cmp, err := result.Cmp(test.expected); err != nil || cmp != 0 {
+				t.Errorf("expecting %v, got: %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tt := []struct {
+		name     string
+		money    Money
+		ratios   []int
+		expected []string
+		err      bool
+	}{
+		{name: "equal parts", money: mustParseMoney("10", EUR), ratios: []int{1, 1, 1}, expected: []string{"3.34", "3.33", "3.33"}},
+		{name: "weighted parts", money: mustParseMoney("0.05", EUR), ratios: []int{3, 7}, expected: []string{"0.02", "0.03"}},
+		{name: "zero ratio", money: mustParseMoney("1", USD), ratios: []int{0, 1, 1}, expected: []string{"0", "0.5", "0.5"}},
+		{name: "negative money", money: mustParseMoney("-10", EUR), ratios: []int{1, 1, 1}, expected: []string{"-3.34", "-3.33", "-3.33"}},
+		{name: "zero minor units", money: mustParseMoney("100", JPY), ratios: []int{1, 1, 1}, expected: []string{"34", "33", "33"}},
+		{name: "no ratios", money: mustParseMoney("10", EUR), err: true},
+		{name: "negative ratio", money: mustParseMoney("10", EUR), ratios: []int{1, -1}, err: true},
+		{name: "zero ratios", money: mustParseMoney("10", EUR), ratios: []int{0, 0}, err: true},
+		{name: "not whole minor units", money: mustParseMoney("0.125", EUR), ratios: []int{1, 1}, err: true},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			parts, err := test.money.Allocate(test.ratios...)
+			if test.err {
+				if _, ok := err.(InvalidAllocation); !ok {
+					t.Errorf("expecting InvalidAllocation, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(parts) != len(test.expected) {
+				t.Fatalf("expecting %d parts, got: %d", len(test.expected), len(parts))
+			}
+			for i, part := range parts {
+				if cmp, err := part.Cmp(mustParseMoney(test.expected[i], test.money.Currency())); err != nil || cmp != 0 {
+					t.Errorf("expecting part %d to be %s, got: %v", i, test.expected[i], part)
+				}
+			}
+		})
+	}
+}
+
+func ExampleMoney_Allocate() {
+	money := mustParseMoney("100", EUR)
+	parts, err := money.Allocate(1, 2)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(parts)
+	// Output: [33.34 EUR 66.66 EUR]
+}
+
+func ExampleMoney_Add() {
+	_, err := mustParseMoney("10", EUR).Add(mustParseMoney("10", USD))
+	fmt.Println(err)
+	// Output: currency mismatch: EUR and USD
+}
@@ -0,0 +1,69 @@
+package currency
+
+import "math/big"
+
+// RoundingMode defines how value is rounded when it can't be represented with requested number of decimal places.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, and halfway values to the even one (bankers' rounding), eg. 2.5 to 2 and 3.5 to 4.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, and halfway values away from zero (commercial rounding), eg. 2.5 to 3 and -2.5 to -3.
+	RoundHalfUp
+	// RoundDown rounds towards zero (truncates), eg. 2.9 to 2 and -2.9 to -2.
+	RoundDown
+	// RoundUp rounds away from zero, eg. 2.1 to 3 and -2.1 to -3.
+	RoundUp
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfEven:
+		return "half-even"
+	case RoundHalfUp:
+		return "half-up"
+	case RoundDown:
+		return "down"
+	case RoundUp:
+		return "up"
+	}
+	return "unknown"
+}
+
+// Round rounds value to specified number of decimal places using mode. Negative places round to tens, hundreds etc.
+func Round(value *big.Rat, places int, mode RoundingMode) *big.Rat {
+	exp := places
+	if exp < 0 {
+		exp = -exp
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+
+	scaled := new(big.Rat)
+	if places >= 0 {
+		scaled.Mul(value, scale)
+	} else {
+		scaled.Quo(value, scale)
+	}
+
+	// quotient is truncated towards zero, so rounding away from zero means adding sign of value
+	quo, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		away := false
+		switch mode {
+		case RoundUp:
+			away = true
+		case RoundHalfUp, RoundHalfEven:
+			half := new(big.Int).Mul(rem.Abs(rem), big.NewInt(2)).Cmp(scaled.Denom())
+			away = half > 0 || half == 0 && (mode == RoundHalfUp || quo.Bit(0) == 1)
+		}
+		if away {
+			quo.Add(quo, big.NewInt(int64(scaled.Sign())))
+		}
+	}
+
+	rounded := new(big.Rat).SetInt(quo)
+	if places >= 0 {
+		return rounded.Quo(rounded, scale)
+	}
+	return rounded.Mul(rounded, scale)
+}
@@ -0,0 +1,77 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tt := []struct {
+		value    string
+		places   int
+		mode     RoundingMode
+		expected string
+	}{
+		{value: "2.5", places: 0, mode: RoundHalfEven, expected: "2"},
+		{value: "3.5", places: 0, mode: RoundHalfEven, expected: "4"},
+		{value: "-2.5", places: 0, mode: RoundHalfEven, expected: "-2"},
+		{value: "-3.5", places: 0, mode: RoundHalfEven, expected: "-4"},
+		{value: "2.51", places: 0, mode: RoundHalfEven, expected: "3"},
+		{value: "2.5", places: 0, mode: RoundHalfUp, expected: "3"},
+		{value: "-2.5", places: 0, mode: RoundHalfUp, expected: "-3"},
+		{value: "2.49", places: 0, mode: RoundHalfUp, expected: "2"},
+		{value: "2.9", places: 0, mode: RoundDown, expected: "2"},
+		{value: "-2.9", places: 0, mode: RoundDown, expected: "-2"},
+		{value: "2.1", places: 0, mode: RoundUp, expected: "3"},
+		{value: "-2.1", places: 0, mode: RoundUp, expected: "-3"},
+		{value: "1.005", places: 2, mode: RoundHalfEven, expected: "1"},
+		{value: "1.015", places: 2, mode: RoundHalfEven, expected: "1.02"},
+		{value: "1.005", places: 2, mode: RoundHalfUp, expected: "1.01"},
+		{value: "9.277404108343935", places: 2, mode: RoundHalfEven, expected: "9.28"},
+		{value: "1/3", places: 4, mode: RoundUp, expected: "0.3334"},
+		{value: "1250", places: -2, mode: RoundHalfEven, expected: "1200"},
+		{value: "1250", places: -2, mode: RoundHalfUp, expected: "1300"},
+		{value: "1.2", places: 2, mode: RoundUp, expected: "1.2"},
+	}
+
+	for _, test := range tt {
+		t.Run(fmt.Sprintf("%s %d %v", test.value, test.places, test.mode), func(t *testing.T) {
+			value, _ := new(big.Rat).SetString(test.value)
+			expected, _ := new(big.Rat).SetString(test.expected)
+			if rounded := Round(value, test.places, test.mode); rounded.Cmp(expected) != 0 {
+				t.Errorf("expecting %v, got: %v", expected, rounded)
+			}
+		})
+	}
+}
+
+func TestCurrency_MinorUnits(t *testing.T) {
+	tt := []struct {
+		currency Currency
+		expected int
+	}{
+		{currency: EUR, expected: 2},
+		{currency: USD, expected: 2},
+		{currency: JPY, expected: 0},
+		{currency: ISK, expected: 0},
+		{currency: KRW, expected: 0},
+	}
+
+	for _, test := range tt {
+		t.Run(string(test.currency), func(t *testing.T) {
+			if units := test.currency.MinorUnits(); units != test.expected {
+				t.Errorf("expecting %d minor units, got: %d", test.expected, units)
+			}
+		})
+	}
+}
+
+func ExampleCurrency_Round() {
+	value := big.NewRat(123455, 1000)
+	fmt.Println(EUR.Round(value, RoundHalfEven).FloatString(EUR.MinorUnits()))
+	fmt.Println(JPY.Round(value, RoundHalfEven).FloatString(JPY.MinorUnits()))
+	// Output:
+	// 123.46
+	// 123
+}
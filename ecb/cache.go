@@ -0,0 +1,38 @@
+package ecb
+
+import "time"
+
+// DefaultCacheTTL is default minimum time between two fetches of rates which are not published yet.
+const DefaultCacheTTL = time.Minute * 5
+
+// historyStart is the first date with rates published by ECB.
+var historyStart = NewDate(1999, time.January, 4)
+
+// CachePolicy decides when cached rates are stale and must be fetched again. Rates published by ECB never change,
+// so cache is refreshed only when queried date is newer than cached rates and ECB could have published new rates since,
+// see LatestPublication. To avoid fetching on each conversion while ECB is late with publication, rates are not fetched
+// again until TTL after previous fetch passed.
+type CachePolicy struct {
+	ttl time.Duration
+}
+
+// NewCachePolicy creates CachePolicy object.
+func NewCachePolicy(ttl time.Duration) *CachePolicy {
+	return &CachePolicy{ttl: ttl}
+}
+
+// stale checks whether cached rates must be fetched again in order to look up rates for date at time now.
+func (p *CachePolicy) stale(cached *Rates, date Date, now time.Time) bool {
+	if date.Before(cached.first) {
+		// older rates are available only if cache doesn't contain complete history
+		return cached.first.After(historyStart)
+	}
+	if !date.After(cached.last) {
+		// missing date inside of cached range is not a business day
+		return false
+	}
+	if now.Sub(cached.fetchedAt) < p.ttl {
+		return false
+	}
+	return LatestPublication(now).After(cached.last)
+}
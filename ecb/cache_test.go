@@ -0,0 +1,120 @@
+package ecb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestCachePolicy_stale(t *testing.T) {
+	// Thursday 24. March 2022 rates fetched on the same day after publication
+	cached := &Rates{
+		first:     NewDate(2022, time.March, 1),
+		last:      NewDate(2022, time.March, 24),
+		fetchedAt: time.Date(2022, time.March, 24, 16, 30, 0, 0, Location),
+	}
+
+	tt := []struct {
+		name     string
+		date     Date
+		now      time.Time
+		ttl      time.Duration
+		expected bool
+	}{
+		{
+			name:     "date older than cache",
+			date:     NewDate(2022, time.February, 1),
+			now:      time.Date(2022, time.March, 24, 17, 0, 0, 0, Location),
+			expected: true,
+		},
+		{
+			name:     "date inside of cache",
+			date:     NewDate(2022, time.March, 13),
+			now:      time.Date(2022, time.March, 24, 17, 0, 0, 0, Location),
+			expected: false,
+		},
+		{
+			name:     "today before publication",
+			date:     NewDate(2022, time.March, 25),
+			now:      time.Date(2022, time.March, 25, 10, 0, 0, 0, Location),
+			expected: false,
+		},
+		{
+			name:     "today after publication",
+			date:     NewDate(2022, time.March, 25),
+			now:      time.Date(2022, time.March, 25, 16, 5, 0, 0, Location),
+			expected: true,
+		},
+		{
+			name:     "today after publication within TTL",
+			date:     NewDate(2022, time.March, 25),
+			now:      time.Date(2022, time.March, 25, 16, 5, 0, 0, Location),
+			ttl:      time.Hour * 24,
+			expected: false,
+		},
+		{
+			name:     "weekend after last publication",
+			date:     NewDate(2022, time.March, 26),
+			now:      time.Date(2022, time.March, 26, 18, 0, 0, 0, Location),
+			expected: true,
+		},
+		{
+			name:     "future date",
+			date:     NewDate(2022, time.April, 26),
+			now:      time.Date(2022, time.March, 24, 18, 0, 0, 0, Location),
+			expected: false,
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			if stale := NewCachePolicy(test.ttl).stale(cached, test.date, test.now); stale != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, stale)
+			}
+		})
+	}
+}
+
+func TestCachePolicy_stale_completeHistory(t *testing.T) {
+	cached := &Rates{first: historyStart, last: NewDate(2022, time.March, 24)}
+	if NewCachePolicy(0).stale(cached, NewDate(1998, time.December, 31), time.Now()) {
+		t.Error("expecting complete history not to be fetched again")
+	}
+}
+
+func TestECBConverter_GetRates_beforePublication(t *testing.T) {
+	calls := 0
+	client := &ECBClientMock{GetFeedMock: func(feed Feed) (*ECBResponseData, error) {
+		calls++
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "USD", Rate: 2}}}},
+		}, nil
+	}}
+	now := time.Date(2022, time.March, 25, 10, 0, 0, 0, Location)
+	converter := New(client, true, log.New(), WithLookupPolicy(LookupPrevious))
+	converter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		value, err := converter.Convert(now, 10, currency.EUR, currency.USD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != 20 {
+			t.Errorf("expecting 20, got: %v", value)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expecting single fetch before publication, got: %d", calls)
+	}
+
+	// once new rates are published, cache is refreshed
+	now = time.Date(2022, time.March, 25, 16, 30, 0, 0, Location)
+	if _, err := converter.Convert(now, 10, currency.EUR, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expecting cache refresh after publication, got %d fetches", calls)
+	}
+}
@@ -0,0 +1,79 @@
+package ecb
+
+import "time"
+
+// PublicationHour is hour of the day (in ECB time zone) around which ECB publishes rates on business days.
+const PublicationHour = 16
+
+// IsBusinessDay checks whether ECB publishes rates on date, ie. date is neither weekend nor TARGET holiday
+// (New Year's Day, Good Friday, Easter Monday, Labour Day, Christmas Day and Boxing Day).
+func IsBusinessDay(date Date) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+
+	switch {
+	case date.Month == time.January && date.Day == 1,
+		date.Month == time.May && date.Day == 1,
+		date.Month == time.December && (date.Day == 25 || date.Day == 26):
+		return false
+	}
+
+	easter := easterSunday(date.Year)
+	if date == easter.AddDays(-2) || date == easter.AddDays(1) {
+		return false
+	}
+	return true
+}
+
+// previousBusinessDay returns the last business day before date.
+func previousBusinessDay(date Date) Date {
+	date = date.AddDays(-1)
+	for !IsBusinessDay(date) {
+		date = date.AddDays(-1)
+	}
+	return date
+}
+
+// nextBusinessDay returns the first business day after date.
+func nextBusinessDay(date Date) Date {
+	date = date.AddDays(1)
+	for !IsBusinessDay(date) {
+		date = date.AddDays(1)
+	}
+	return date
+}
+
+// PublicationTime returns time around which ECB publishes rates for date.
+func PublicationTime(date Date) time.Time {
+	return date.Time().Add(time.Hour * PublicationHour)
+}
+
+// LatestPublication returns the last business day for which ECB should have published rates until t.
+func LatestPublication(t time.Time) Date {
+	date := DateOf(t)
+	if !IsBusinessDay(date) || t.Before(PublicationTime(date)) {
+		return previousBusinessDay(date)
+	}
+	return date
+}
+
+// easterSunday calculates date of Easter Sunday in Gregorian calendar using anonymous Gregorian algorithm.
+func easterSunday(year int) Date {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Date{Year: year, Month: time.Month(month), Day: day}
+}
@@ -0,0 +1,61 @@
+package ecb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleLatestPublication() {
+	// Good Friday 15. April 2022
+	fmt.Println(LatestPublication(time.Date(2022, time.April, 15, 12, 0, 0, 0, Location)))
+	fmt.Println(LatestPublication(time.Date(2022, time.April, 19, 15, 0, 0, 0, Location)))
+	fmt.Println(LatestPublication(time.Date(2022, time.April, 19, 16, 30, 0, 0, Location)))
+	// Output:
+	// 2022-04-14
+	// 2022-04-14
+	// 2022-04-19
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	tt := []struct {
+		date     Date
+		expected bool
+	}{
+		{date: NewDate(2022, time.March, 25), expected: true},
+		{date: NewDate(2022, time.March, 26), expected: false},
+		{date: NewDate(2022, time.March, 27), expected: false},
+		{date: NewDate(2022, time.January, 1), expected: false},
+		{date: NewDate(2021, time.April, 2), expected: false},
+		{date: NewDate(2021, time.April, 5), expected: false},
+		{date: NewDate(2021, time.April, 6), expected: true},
+		{date: NewDate(2023, time.May, 1), expected: false},
+		{date: NewDate(2023, time.December, 25), expected: false},
+		{date: NewDate(2023, time.December, 26), expected: false},
+		{date: NewDate(2023, time.December, 27), expected: true},
+	}
+
+	for _, test := range tt {
+		t.Run(test.date.String(), func(t *testing.T) {
+			if ok := IsBusinessDay(test.date); ok != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, ok)
+			}
+		})
+	}
+}
+
+func TestEasterSunday(t *testing.T) {
+	expected := map[int]Date{
+		1999: NewDate(1999, time.April, 4),
+		2008: NewDate(2008, time.March, 23),
+		2019: NewDate(2019, time.April, 21),
+		2022: NewDate(2022, time.April, 17),
+		2024: NewDate(2024, time.March, 31),
+		2038: NewDate(2038, time.April, 25),
+	}
+	for year, date := range expected {
+		if easter := easterSunday(year); easter != date {
+			t.Errorf("expected Easter %v, got %v", date, easter)
+		}
+	}
+}
@@ -1,8 +1,8 @@
 package ecb
 
 import (
-	"encoding/xml"
-	"io/ioutil"
+	"context"
+	"strings"
 	"time"
 
 	"net/http"
@@ -12,18 +12,59 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// Feed is path of XML document with rates published by ECB.
+type Feed string
+
 const (
-	getRatesPath = "stats/eurofxref/eurofxref-hist-90d.xml"
+	// FeedDaily holds rates for the latest day only.
+	FeedDaily = Feed("stats/eurofxref/eurofxref-daily.xml")
+	// Feed90Days holds rates for the last 90 days.
+	Feed90Days = Feed("stats/eurofxref/eurofxref-hist-90d.xml")
+	// FeedHistory holds complete history of rates, starting from 4. January 1999.
+	FeedHistory = Feed("stats/eurofxref/eurofxref-hist.xml")
 )
 
-// ECBClientInterface defines ECB client API.
+// feeds lists all feeds ordered from the smallest to the largest one.
+var feeds = []Feed{FeedDaily, Feed90Days, FeedHistory}
+
+// next returns first feed which is larger than f.
+func (f Feed) next() (Feed, bool) {
+	for i, feed := range feeds {
+		if feed == f && i+1 < len(feeds) {
+			return feeds[i+1], true
+		}
+	}
+	return "", false
+}
+
+// ECBClientInterface defines ECB client API. GetRates fetches rates for the last 90 days, ie. Feed90Days.
 type ECBClientInterface interface {
 	GetRates() (*ECBResponseData, error)
 }
 
+// ECBFeedClientInterface is implemented by clients which can fetch any Feed. ECBConverter uses it when available,
+// so rates older than 90 days can be converted, otherwise it falls back to GetRates.
+type ECBFeedClientInterface interface {
+	GetFeed(feed Feed) (*ECBResponseData, error)
+}
+
+// ECBContextClientInterface is implemented by clients which can fetch any Feed and abort fetching once ctx is done.
+// ECBConverter prefers it over ECBFeedClientInterface.
+type ECBContextClientInterface interface {
+	GetFeedContext(ctx context.Context, feed Feed) (*ECBResponseData, error)
+}
+
+// ECBStreamClientInterface is implemented by clients which can stream feed day by day, see DecodeFeed.
+// ECBConverter uses it when available, so rates are built without holding decoded feed in memory.
+type ECBStreamClientInterface interface {
+	StreamFeedContext(ctx context.Context, feed Feed, fn func(day DataXML) error) error
+}
+
 // ECBClientMock type used for mocking http layer in tests.
 type ECBClientMock struct {
-	GetRatesMock func() (*ECBResponseData, error)
+	GetRatesMock       func() (*ECBResponseData, error)
+	GetFeedMock        func(feed Feed) (*ECBResponseData, error)
+	GetFeedContextMock func(ctx context.Context, feed Feed) (*ECBResponseData, error)
 }
 
 // GetRates calls GetRatesMock.
@@ -31,20 +72,78 @@ func (c *ECBClientMock) GetRates() (*ECBResponseData, error) {
 	return c.GetRatesMock()
 }
 
+// GetFeed calls GetFeedMock, or GetRatesMock when GetFeedMock is not set.
+func (c *ECBClientMock) GetFeed(feed Feed) (*ECBResponseData, error) {
+	if c.GetFeedMock == nil {
+		return c.GetRatesMock()
+	}
+	return c.GetFeedMock(feed)
+}
+
+// GetFeedContext calls GetFeedContextMock, or GetFeed when GetFeedContextMock is not set and ctx is not done.
+func (c *ECBClientMock) GetFeedContext(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+	if c.GetFeedContextMock == nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return c.GetFeed(feed)
+	}
+	return c.GetFeedContextMock(ctx, feed)
+}
+
+// RequestDecorator modifies http request before it is sent to ECB, eg. sets headers or credentials.
+type RequestDecorator func(req *http.Request) error
+
 // ECBOptions allow client configuration, eg: specify retry count and their delay.
+// Optionally, http client used for requests and request decorators can be set, see WithHTTPClient and WithRequestDecorator.
 type ECBOptions struct {
-	retry int
-	wait  time.Duration
+	retry      int
+	wait       time.Duration
+	httpClient *http.Client
+	decorators []RequestDecorator
+	format     FeedFormat
 }
 
-// NewECBOptions creates ECBOptions object.
+// NewECBOptions creates ECBOptions object. By default, http.DefaultClient is used for requests.
 func NewECBOptions(retry int, wait time.Duration) *ECBOptions {
 	return &ECBOptions{
-		retry: retry,
-		wait:  wait,
+		retry:      retry,
+		wait:       wait,
+		httpClient: http.DefaultClient,
 	}
 }
 
+// WithHTTPClient sets http client used for requests, eg. client with timeout, proxy or custom TLS configuration.
+func (o *ECBOptions) WithHTTPClient(client *http.Client) *ECBOptions {
+	o.httpClient = client
+	return o
+}
+
+// WithTransport sets http client which uses specified transport for requests, eg. recording transport in tests.
+func (o *ECBOptions) WithTransport(transport http.RoundTripper) *ECBOptions {
+	return o.WithHTTPClient(&http.Client{Transport: transport})
+}
+
+// WithFeedFormat sets format in which feeds are downloaded. Default is FeedFormatXML.
+func (o *ECBOptions) WithFeedFormat(format FeedFormat) *ECBOptions {
+	o.format = format
+	return o
+}
+
+// WithRequestDecorator adds decorator applied on each request, including retries. Decorators are applied in order they are added.
+func (o *ECBOptions) WithRequestDecorator(decorator RequestDecorator) *ECBOptions {
+	o.decorators = append(o.decorators, decorator)
+	return o
+}
+
+// WithUserAgent adds decorator which sets User-Agent header of each request.
+func (o *ECBOptions) WithUserAgent(userAgent string) *ECBOptions {
+	return o.WithRequestDecorator(func(req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
 // ECBClient implements ECBClientInterface, therefore implements how rates are fetched via ECB. Additionally it can be configured using ECBOptions.
 type ECBClient struct {
 	logger  *log.Logger
@@ -63,27 +162,90 @@ func NewECBClient(scheme, host string, options *ECBOptions, logger *log.Logger)
 	}
 }
 
-// GetRates makes http request to ECB to fetch rates data in form of XML. In case of non 2xx status code it fails with ECBClientError.
-// If code is 5xx then it will try to retry requests using policy specified in ECBOptions.
+// GetRates fetches rates for the last 90 days, see GetFeed.
 func (c *ECBClient) GetRates() (*ECBResponseData, error) {
-	url := url.URL{
-		Scheme: c.scheme,
-		Host:   c.host,
-		Path:   getRatesPath,
+	return c.GetFeed(Feed90Days)
+}
+
+// GetRatesContext fetches rates for the last 90 days, see GetFeedContext.
+func (c *ECBClient) GetRatesContext(ctx context.Context) (*ECBResponseData, error) {
+	return c.GetFeedContext(ctx, Feed90Days)
+}
+
+// GetFeed fetches rates data of specified feed, see GetFeedContext.
+func (c *ECBClient) GetFeed(feed Feed) (*ECBResponseData, error) {
+	return c.GetFeedContext(context.Background(), feed)
+}
+
+// GetFeedContext makes http request to ECB to fetch rates data of specified feed in format set by ECBOptions, see FeedFormat. In case of non 2xx status code it fails with RequestError wrapping ECBClientError.
+// If code is 5xx then it will try to retry requests using policy specified in ECBOptions.
+// Cancelling ctx aborts both ongoing request and waiting for the next retry, in which case ctx error is returned.
+// Response which is not valid ECB feed results in FeedParseError.
+func (c *ECBClient) GetFeedContext(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+	data := &ECBResponseData{}
+	err := c.StreamFeedContext(ctx, feed, func(day DataXML) error {
+		data.Data = append(data.Data, day)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return data, nil
+}
 
+// StreamFeedContext is same as GetFeedContext, but instead of returning complete feed it calls fn for every day of rates
+// while response is being read, see DecodeFeed.
+func (c *ECBClient) StreamFeedContext(ctx context.Context, feed Feed, fn func(day DataXML) error) error {
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: c.path(feed)}
+	resp, err := c.do(ctx, u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decode := DecodeFeed
+	if strings.HasSuffix(u.Path, ".zip") {
+		decode = DecodeZIPFeed
+	}
+	if err := decode(resp.Body, fn); err != nil {
+		c.logger.Errorf("[GET] %v: %v", u.String(), err)
+		return err
+	}
+	return nil
+}
+
+// path returns path of feed in format set by ECBOptions.
+func (c *ECBClient) path(feed Feed) string {
+	if c.options.format == FeedFormatZIP {
+		if path, ok := zipFeeds[feed]; ok {
+			return path
+		}
+	}
+	return string(feed)
+}
+
+// do makes GET request with header to url, retrying on 5xx status codes. Body of returned response must be closed.
+// Failed request results in RequestError, which holds url, number of attempts and error of the last attempt.
+func (c *ECBClient) do(ctx context.Context, url url.URL, header http.Header) (*http.Response, error) {
 	var resp *http.Response
-	var err error
+	attempts := 0
 
-	err = retry.Do(
+	err := retry.Do(
 		func() error {
-			resp, err = http.Get(url.String())
+			attempts++
+			req, err := c.newRequest(ctx, url.String(), header)
+			if err != nil {
+				// failing request decorator won't succeed on retry
+				return retry.Unrecoverable(err)
+			}
+			resp, err = c.httpClient().Do(req)
 			if err != nil {
 				return err
 			}
 
 			// returning error will result in retry, so 5xx errors are retryable
 			if resp.StatusCode/100 == 5 {
+				resp.Body.Close()
 				return ECBClientError{statusCode: resp.StatusCode}
 			}
 
@@ -92,26 +254,47 @@ func (c *ECBClient) GetRates() (*ECBResponseData, error) {
 		// first attempt is not retry, therefore retry+1
 		retry.Attempts(uint(c.options.retry+1)),
 		retry.Delay(c.options.wait),
+		retry.Context(ctx),
+		retry.LastErrorOnly(true),
 		retry.OnRetry(func(n uint, err error) {
 			c.logger.Errorf("[retry=%d] retrying on %v", n, err)
 		}),
 	)
 	if err != nil {
-		return nil, err
+		return nil, RequestError{url: url.String(), attempts: attempts, err: err}
 	}
 
 	if resp.StatusCode/100 != 2 {
 		// eg. 4xx is not retryable and should throw an error
+		resp.Body.Close()
 		c.logger.Errorf("[GET] %v: code=%d", url.String(), resp.StatusCode)
-		return nil, ECBClientError{statusCode: resp.StatusCode}
+		return nil, RequestError{url: url.String(), attempts: attempts, err: ECBClientError{statusCode: resp.StatusCode}}
 	}
 	c.logger.Debugf("[GET] %v: code=%d", url.String(), resp.StatusCode)
+	return resp, nil
+}
 
-	respDataBytes, err := ioutil.ReadAll(resp.Body)
+// httpClient returns http client from ECBOptions, falling back to http.DefaultClient.
+func (c *ECBClient) httpClient() *http.Client {
+	if c.options.httpClient == nil {
+		return http.DefaultClient
+	}
+	return c.options.httpClient
+}
+
+// newRequest creates GET request for url with header and applies all request decorators from ECBOptions.
+func (c *ECBClient) newRequest(ctx context.Context, url string, header http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
-	ecbData := ECBResponseData{}
-	xml.Unmarshal(respDataBytes, &ecbData)
-	return &ecbData, err
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	for _, decorate := range c.options.decorators {
+		if err := decorate(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
 }
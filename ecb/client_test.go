@@ -1,17 +1,38 @@
 package ecb
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
-	"github.com/avast/retry-go"
 	log "github.com/sirupsen/logrus"
 )
 
+// testFeed is ECB daily feed as published on https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml.
+const testFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2022-03-25'>
+			<Cube currency='USD' rate='1.0983'/>
+			<Cube currency='JPY' rate='134.19'/>
+			<Cube currency='CHF' rate='1.0165'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
 func TestECBClient_GetRates(t *testing.T) {
 	tt := []struct {
 		name    string
@@ -46,15 +67,33 @@ func TestECBClient_GetRates(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "HTML error page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("<html><body>Service unavailable</body></html>"))
+			},
+			verify: func(data *ECBResponseData, err error) {
+				if _, ok := err.(FeedParseError); !ok {
+					t.Fatalf("expecting FeedParseError, got: %v", err)
+				}
+			},
+		},
 		{
 			name: "4xx error",
 			handler: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusBadRequest)
 			},
 			verify: func(data *ECBResponseData, err error) {
-				if _, ok := err.(ECBClientError); !ok {
+				var clientErr ECBClientError
+				if !errors.As(err, &clientErr) {
 					t.Fatalf("expecting ECBClientError from server, got: %v", err)
 				}
+				if clientErr.StatusCode() != http.StatusBadRequest {
+					t.Errorf("expecting status code %d, got: %d", http.StatusBadRequest, clientErr.StatusCode())
+				}
+				if !errors.Is(err, ErrHTTPStatus) {
+					t.Errorf("expecting ErrHTTPStatus, got: %v", err)
+				}
 			},
 		},
 		{
@@ -63,8 +102,16 @@ func TestECBClient_GetRates(t *testing.T) {
 				w.WriteHeader(http.StatusInternalServerError)
 			},
 			verify: func(data *ECBResponseData, err error) {
-				if _, ok := err.(retry.Error); !ok {
-					t.Fatalf("expecting retry.Error from server, got: %v", err)
+				var requestErr RequestError
+				if !errors.As(err, &requestErr) {
+					t.Fatalf("expecting RequestError from server, got: %v", err)
+				}
+				if requestErr.Attempts() != 1 || !strings.HasSuffix(requestErr.URL(), "/"+string(Feed90Days)) {
+					t.Errorf("expecting single attempt of %s, got: %v", Feed90Days, requestErr)
+				}
+				var clientErr ECBClientError
+				if !errors.As(err, &clientErr) || clientErr.StatusCode() != http.StatusInternalServerError {
+					t.Errorf("expecting wrapped ECBClientError with status code %d, got: %v", http.StatusInternalServerError, err)
 				}
 			},
 		},
@@ -101,3 +148,162 @@ func TestECBClient_GetRates_retry(t *testing.T) {
 		}
 	}
 }
+
+func TestECBClient_GetFeed(t *testing.T) {
+	for _, feed := range []Feed{FeedDaily, Feed90Days, FeedHistory} {
+		t.Run(string(feed), func(t *testing.T) {
+			var path string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				_, _ = w.Write([]byte(testFeed))
+			}))
+			defer ts.Close()
+
+			url, _ := url.Parse(ts.URL)
+			client := NewECBClient(url.Scheme, url.Host, NewECBOptions(0, time.Second), log.New())
+			if _, err := client.GetFeed(feed); err != nil {
+				t.Fatal(err)
+			}
+			if path != "/"+string(feed) {
+				t.Errorf("expecting path /%s, got: %s", feed, path)
+			}
+		})
+	}
+}
+
+func TestECBClient_GetFeedContext_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var called int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&called, 1)
+		cancel()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	url, _ := url.Parse(ts.URL)
+	client := NewECBClient(url.Scheme, url.Host, NewECBOptions(3, time.Hour), log.New())
+
+	done := make(chan error)
+	go func() {
+		_, err := client.GetFeedContext(ctx, Feed90Days)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expecting context.Canceled, got: %v", err)
+		}
+		if called := atomic.LoadInt32(&called); called != 1 {
+			t.Errorf("expecting single request, got: %d", called)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("retry was not cancelled")
+	}
+}
+
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestECBClient_GetFeed_options(t *testing.T) {
+	var userAgent, token string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		token = r.Header.Get("X-Token")
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer ts.Close()
+
+	transport := &recordingTransport{}
+	options := NewECBOptions(0, time.Second).
+		WithTransport(transport).
+		WithUserAgent("eurex-test").
+		WithRequestDecorator(func(req *http.Request) error {
+			req.Header.Set("X-Token", "secret")
+			return nil
+		})
+
+	url, _ := url.Parse(ts.URL)
+	client := NewECBClient(url.Scheme, url.Host, options, log.New())
+	if _, err := client.GetRates(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transport.requests) != 1 {
+		t.Errorf("expecting request through custom transport, got: %d", len(transport.requests))
+	}
+	if userAgent != "eurex-test" {
+		t.Errorf("expecting User-Agent eurex-test, got: %s", userAgent)
+	}
+	if token != "secret" {
+		t.Errorf("expecting X-Token header set by decorator, got: %s", token)
+	}
+}
+
+func TestECBClient_GetFeed_decoratorError(t *testing.T) {
+	called := 0
+	options := NewECBOptions(3, time.Second*0).WithRequestDecorator(func(req *http.Request) error {
+		called++
+		return errors.New("missing credentials")
+	})
+
+	client := NewECBClient("http", "localhost", options, log.New())
+	if _, err := client.GetRates(); err == nil {
+		t.Error("expecting decorator error")
+	}
+	if called != 1 {
+		t.Errorf("expecting decorator error not to be retried, got %d calls", called)
+	}
+}
+
+// stubTransport serves body without making any request. Like most custom transports, it doesn't set Request of response.
+type stubTransport struct {
+	status int
+	body   []byte
+}
+
+func (t stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: t.status, Body: ioutil.NopCloser(bytes.NewReader(t.body))}, nil
+}
+
+func TestECBClient_GetFeed_stubTransport(t *testing.T) {
+	tt := []struct {
+		name   string
+		format FeedFormat
+		body   []byte
+		valid  bool
+	}{
+		{name: "xml", format: FeedFormatXML, body: []byte(testFeed), valid: true},
+		{name: "zip", format: FeedFormatZIP, body: zipFeed("eurofxref.csv", testDailyCSV), valid: true},
+		{name: "invalid feed", format: FeedFormatXML, body: []byte("<html></html>")},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			options := NewECBOptions(0, time.Second).
+				WithTransport(stubTransport{status: http.StatusOK, body: test.body}).
+				WithFeedFormat(test.format)
+			client := NewECBClient("https", "www.ecb.europa.eu", options, log.New())
+			data, err := client.GetFeed(FeedDaily)
+			if !test.valid {
+				if _, ok := err.(FeedParseError); !ok {
+					t.Errorf("expecting FeedParseError, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data.Data) != 1 || data.Data[0].Date != "2022-03-25" {
+				t.Errorf("expecting rates for 2022-03-25, got: %v", data.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package ecb
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedFormat defines format in which feeds are downloaded from ECB.
+type FeedFormat int
+
+const (
+	// FeedFormatXML downloads feeds as XML documents, see DecodeFeed.
+	FeedFormatXML FeedFormat = iota
+	// FeedFormatZIP downloads feeds as ZIP archives with CSV file, see DecodeZIPFeed. ZIP archives are considerably smaller
+	// than XML documents, but ECB publishes them only for FeedDaily and FeedHistory, so Feed90Days is still downloaded as XML.
+	FeedFormatZIP
+)
+
+func (f FeedFormat) String() string {
+	switch f {
+	case FeedFormatXML:
+		return "xml"
+	case FeedFormatZIP:
+		return "zip"
+	}
+	return "unknown"
+}
+
+// zipFeeds maps feeds to paths of corresponding ZIP archives published by ECB.
+var zipFeeds = map[Feed]string{
+	FeedDaily:   "stats/eurofxref/eurofxref.zip",
+	FeedHistory: "stats/eurofxref/eurofxref-hist.zip",
+}
+
+// csvDateLayouts are layouts of dates in ECB CSV files. History uses ISO dates, while daily file uses eg. "25 March 2022".
+var csvDateLayouts = []string{"2006-01-02", "2 January 2006"}
+
+// DecodeZIPFeed decodes ECB feed from ZIP archive (eg. https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.zip)
+// and calls fn for every day of rates, see DecodeCSVFeed. Archive is read into memory, since ZIP format requires
+// random access, but it is still much smaller than equivalent XML document.
+func DecodeZIPFeed(r io.Reader, fn func(day DataXML) error) error {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return FeedParseError{msg: err.Error()}
+	}
+	for _, file := range archive.File {
+		if strings.ToLower(path.Ext(file.Name)) != ".csv" {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return FeedParseError{msg: err.Error()}
+		}
+		defer f.Close()
+		return DecodeCSVFeed(f, fn)
+	}
+	return FeedParseError{msg: "no CSV file found in ZIP archive"}
+}
+
+// DecodeCSVFeed decodes ECB feed from CSV file, where the first column holds dates and the other ones hold rates of
+// currencies named in header, and calls fn for every day of rates. Cells of currencies not quoted on certain day
+// hold "N/A" and are skipped, as well as trailing empty column. Days are validated the same way as by DecodeFeed.
+func DecodeCSVFeed(r io.Reader, fn func(day DataXML) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return FeedParseError{msg: fmt.Sprintf("failed to read CSV header: %v", err)}
+	}
+	if len(header) == 0 || strings.TrimSpace(header[0]) != "Date" {
+		return FeedParseError{msg: "missing Date column in CSV header"}
+	}
+	currencies := make([]string, len(header)-1)
+	for i, code := range header[1:] {
+		currencies[i] = strings.TrimSpace(code)
+	}
+
+	days := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return FeedParseError{msg: err.Error()}
+		}
+
+		date, err := parseCSVDate(record[0])
+		if err != nil {
+			return err
+		}
+		day := DataXML{Date: date}
+		for i, cell := range record[1:] {
+			cell = strings.TrimSpace(cell)
+			if cell == "" || cell == "N/A" {
+				continue
+			}
+			if i >= len(currencies) || currencies[i] == "" {
+				return FeedParseError{msg: fmt.Sprintf("rate without currency for %s: %s", date, cell)}
+			}
+			rate, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return FeedParseError{msg: fmt.Sprintf("invalid %s rate for %s: %s", currencies[i], date, cell)}
+			}
+			day.Rates = append(day.Rates, RateXML{Currency: currencies[i], Rate: rate})
+		}
+
+		if err := validateDay(day, days); err != nil {
+			return err
+		}
+		days++
+		if err := fn(day); err != nil {
+			return err
+		}
+	}
+	if days == 0 {
+		return FeedParseError{msg: "no rates found in CSV file"}
+	}
+	return nil
+}
+
+// parseCSVDate parses date in one of csvDateLayouts into format used by XML feeds.
+func parseCSVDate(value string) (DateXML, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return DateXML(t.Format("2006-01-02")), nil
+		}
+	}
+	return "", FeedParseError{msg: fmt.Sprintf("invalid date in CSV file: %s", value)}
+}
@@ -0,0 +1,160 @@
+package ecb
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// testHistoryCSV resembles eurofxref-hist.csv published by ECB.
+const testHistoryCSV = `Date,USD,JPY,CYP,HRK,
+2023-01-02,1.0683,140.66,N/A,N/A,
+2022-12-30,1.0666,140.66,N/A,7.5365,
+`
+
+// testDailyCSV resembles eurofxref.csv published by ECB.
+const testDailyCSV = `Date, USD, JPY, CHF, 
+25 March 2022, 1.0983, 134.19, 1.0165, 
+`
+
+func zipFeed(name, content string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	f, _ := archive.Create(name)
+	_, _ = f.Write([]byte(content))
+	_ = archive.Close()
+	return buf.Bytes()
+}
+
+func TestDecodeCSVFeed(t *testing.T) {
+	tt := []struct {
+		name     string
+		csv      string
+		expected []DataXML
+		valid    bool
+	}{
+		{
+			name: "history",
+			csv:  testHistoryCSV,
+			expected: []DataXML{
+				{Date: "2023-01-02", Rates: []RateXML{{Currency: "USD", Rate: 1.0683}, {Currency: "JPY", Rate: 140.66}}},
+				{Date: "2022-12-30", Rates: []RateXML{{Currency: "USD", Rate: 1.0666}, {Currency: "JPY", Rate: 140.66}, {Currency: "HRK", Rate: 7.5365}}},
+			},
+			valid: true,
+		},
+		{
+			name: "daily",
+			csv:  testDailyCSV,
+			expected: []DataXML{
+				{Date: "2022-03-25", Rates: []RateXML{{Currency: "USD", Rate: 1.0983}, {Currency: "JPY", Rate: 134.19}, {Currency: "CHF", Rate: 1.0165}}},
+			},
+			valid: true,
+		},
+		{name: "empty", csv: ""},
+		{name: "missing Date column", csv: "USD,JPY\n1.1,130\n"},
+		{name: "no days", csv: "Date,USD,\n"},
+		{name: "invalid date", csv: "Date,USD,\n25/03/2022,1.1,\n"},
+		{name: "invalid rate", csv: "Date,USD,\n2022-03-25,abc,\n"},
+		{name: "rate without currency", csv: "Date,USD\n2022-03-25,1.1,1.2\n"},
+		{name: "day without rates", csv: "Date,USD,\n2022-03-25,N/A,\n"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			var days []DataXML
+			err := DecodeCSVFeed(strings.NewReader(test.csv), func(day DataXML) error {
+				days = append(days, day)
+				return nil
+			})
+			if !test.valid {
+				if _, ok := err.(FeedParseError); !ok {
+					t.Errorf("expecting FeedParseError, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(days) != fmt.Sprint(test.expected) {
+				t.Errorf("expecting %v, got: %v", test.expected, days)
+			}
+		})
+	}
+}
+
+func TestDecodeZIPFeed(t *testing.T) {
+	t.Run("valid archive", func(t *testing.T) {
+		days := 0
+		err := DecodeZIPFeed(bytes.NewReader(zipFeed("eurofxref-hist.csv", testHistoryCSV)), func(day DataXML) error {
+			days++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if days != 2 {
+			t.Errorf("expecting 2 days, got: %d", days)
+		}
+	})
+
+	t.Run("archive without CSV", func(t *testing.T) {
+		err := DecodeZIPFeed(bytes.NewReader(zipFeed("README.txt", "")), func(day DataXML) error { return nil })
+		if _, ok := err.(FeedParseError); !ok {
+			t.Errorf("expecting FeedParseError, got: %v", err)
+		}
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		err := DecodeZIPFeed(strings.NewReader(testFeed), func(day DataXML) error { return nil })
+		if _, ok := err.(FeedParseError); !ok {
+			t.Errorf("expecting FeedParseError, got: %v", err)
+		}
+	})
+}
+
+func TestECBClient_GetFeed_zip(t *testing.T) {
+	tt := []struct {
+		feed Feed
+		path string
+	}{
+		{feed: FeedDaily, path: "/stats/eurofxref/eurofxref.zip"},
+		{feed: Feed90Days, path: "/stats/eurofxref/eurofxref-hist-90d.xml"},
+		{feed: FeedHistory, path: "/stats/eurofxref/eurofxref-hist.zip"},
+	}
+
+	for _, test := range tt {
+		t.Run(string(test.feed), func(t *testing.T) {
+			var path string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				if strings.HasSuffix(path, ".zip") {
+					_, _ = w.Write(zipFeed("eurofxref.csv", testDailyCSV))
+					return
+				}
+				_, _ = w.Write([]byte(testFeed))
+			}))
+			defer ts.Close()
+
+			url, _ := url.Parse(ts.URL)
+			client := NewECBClient(url.Scheme, url.Host, NewECBOptions(0, time.Second).WithFeedFormat(FeedFormatZIP), log.New())
+			data, err := client.GetFeed(test.feed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != test.path {
+				t.Errorf("expecting path %s, got: %s", test.path, path)
+			}
+			if len(data.Data) != 1 || data.Data[0].Date != "2022-03-25" {
+				t.Errorf("expecting rates for 2022-03-25, got: %v", data.Data)
+			}
+		})
+	}
+}
@@ -6,56 +6,114 @@ import (
 	"github.com/filiptubic/eurex/currency"
 )
 
+// Validity is period during which ECB publishes rates of currency.
+type Validity struct {
+	// First is date of the first published rate. Currencies published since the beginning of ECB history
+	// have First set to 4. January 1999.
+	First Date
+	// Last is date of the last published rate, or zero date when currency is still published.
+	Last Date
+	// Successor is currency which replaced currency after Last (eg. EUR for currencies of euro area members),
+	// or empty when there is none.
+	Successor currency.Currency
+}
+
+// Contains checks whether date falls into validity period.
+func (v Validity) Contains(date Date) bool {
+	if date.Before(v.First) {
+		return false
+	}
+	return v.Last.IsZero() || !date.After(v.Last)
+}
+
 var (
-	validCurrencies = map[currency.Currency]struct{}{
-		currency.AUD: {},
-		currency.BGN: {},
-		currency.BRL: {},
-		currency.CAD: {},
-		currency.CHF: {},
-		currency.CNY: {},
-		currency.CZK: {},
-		currency.DKK: {},
-		currency.EUR: {},
-		currency.GBP: {},
-		currency.HKD: {},
-		currency.HRK: {},
-		currency.ISK: {},
-		currency.HUF: {},
-		currency.IDR: {},
-		currency.ILS: {},
-		currency.INR: {},
-		currency.JPY: {},
-		currency.KRW: {},
-		currency.MXN: {},
-		currency.MYR: {},
-		currency.NOK: {},
-		currency.NZD: {},
-		currency.PHP: {},
-		currency.PLN: {},
-		currency.RON: {},
-		currency.RUB: {},
-		currency.SEK: {},
-		currency.SGD: {},
-		currency.THB: {},
-		currency.TRY: {},
-		currency.USD: {},
-		currency.ZAR: {},
+	// validities holds validity of every currency published by ECB.
+	validities = map[currency.Currency]Validity{
+		currency.AUD: {First: historyStart},
+		currency.BGN: {First: historyStart, Last: NewDate(2025, time.December, 31), Successor: currency.EUR},
+		currency.BRL: {First: NewDate(2008, time.January, 2)},
+		currency.CAD: {First: historyStart},
+		currency.CHF: {First: historyStart},
+		currency.CNY: {First: NewDate(2005, time.April, 1)},
+		currency.CYP: {First: historyStart, Last: NewDate(2007, time.December, 31), Successor: currency.EUR},
+		currency.CZK: {First: historyStart},
+		currency.DKK: {First: historyStart},
+		currency.EEK: {First: historyStart, Last: NewDate(2010, time.December, 31), Successor: currency.EUR},
+		currency.EUR: {First: historyStart},
+		currency.GBP: {First: historyStart},
+		currency.HKD: {First: historyStart},
+		currency.HRK: {First: NewDate(2005, time.April, 1), Last: NewDate(2022, time.December, 30), Successor: currency.EUR},
+		currency.HUF: {First: historyStart},
+		currency.IDR: {First: NewDate(2005, time.April, 1)},
+		currency.ILS: {First: NewDate(2011, time.January, 3)},
+		currency.INR: {First: NewDate(2009, time.January, 2)},
+		currency.ISK: {First: historyStart},
+		currency.JPY: {First: historyStart},
+		currency.KRW: {First: historyStart},
+		currency.LTL: {First: historyStart, Last: NewDate(2014, time.December, 31), Successor: currency.EUR},
+		currency.LVL: {First: historyStart, Last: NewDate(2013, time.December, 31), Successor: currency.EUR},
+		currency.MTL: {First: historyStart, Last: NewDate(2007, time.December, 31), Successor: currency.EUR},
+		currency.MXN: {First: NewDate(2008, time.January, 2)},
+		currency.MYR: {First: NewDate(2005, time.April, 1)},
+		currency.NOK: {First: historyStart},
+		currency.NZD: {First: historyStart},
+		currency.PHP: {First: NewDate(2005, time.April, 1)},
+		currency.PLN: {First: historyStart},
+		currency.RON: {First: NewDate(2005, time.July, 1)},
+		currency.ROL: {First: historyStart, Last: NewDate(2005, time.June, 30), Successor: currency.RON},
+		currency.RUB: {First: NewDate(2005, time.April, 1), Last: NewDate(2022, time.March, 1)},
+		currency.SEK: {First: historyStart},
+		currency.SGD: {First: historyStart},
+		currency.SIT: {First: historyStart, Last: NewDate(2006, time.December, 29), Successor: currency.EUR},
+		currency.SKK: {First: historyStart, Last: NewDate(2008, time.December, 31), Successor: currency.EUR},
+		currency.THB: {First: NewDate(2005, time.April, 1)},
+		currency.TRL: {First: historyStart, Last: NewDate(2004, time.December, 31), Successor: currency.TRY},
+		currency.TRY: {First: NewDate(2005, time.January, 3)},
+		currency.USD: {First: historyStart},
+		currency.ZAR: {First: historyStart},
 	}
 )
 
+// CurrencyValidity returns period during which ECB publishes rates of currency.
+func CurrencyValidity(c currency.Currency) (Validity, bool) {
+	validity, ok := validities[c]
+	return validity, ok
+}
+
 // IsValidCurrency check whether currency is valid for certain date.
 func IsValidCurrency(c currency.Currency, date time.Time) bool {
-	_, ok := validCurrencies[c]
+	return checkCurrency(c, DateOf(date)) == nil
+}
+
+// checkCurrency returns InvalidCurrency when currency is not published by ECB at all, and CurrencyNotQuoted when
+// it is not published on date.
+func checkCurrency(c currency.Currency, date Date) error {
+	validity, ok := validities[c]
 	if !ok {
-		return false
+		return InvalidCurrency{string(c)}
 	}
+	if !validity.Contains(date) {
+		return CurrencyNotQuoted{currency: c, date: date, validity: validity}
+	}
+	return nil
+}
 
-	// Russian RUB is invalid on ECB after 1. March 2022
-	rubValidTo := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.Local)
-	if c == currency.RUB && date.After(rubValidTo) {
+// notQuoted returns CurrencyNotQuoted for currency missing in rates of date. Validity is left empty for currencies
+// which are not known to be published by ECB.
+func notQuoted(c currency.Currency, date Date) error {
+	validity := validities[c]
+	return CurrencyNotQuoted{currency: c, date: date, validity: validity}
+}
+
+// isCurrencyCode checks whether code looks like ISO 4217 alphabetic code, ie. three uppercase letters.
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
 		return false
 	}
-
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
 	return true
 }
@@ -2,6 +2,8 @@ package ecb
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -9,8 +11,8 @@ import (
 )
 
 func ExampleIsValidCurrency() {
-	firstOfMarch := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.Local)
-	secondOfMarch := time.Date(2022, time.March, 2, 0, 0, 0, 0, time.Local)
+	firstOfMarch := time.Date(2022, time.March, 1, 0, 0, 0, 0, Location)
+	secondOfMarch := time.Date(2022, time.March, 2, 0, 0, 0, 0, Location)
 	fmt.Println(IsValidCurrency(currency.RUB, firstOfMarch))
 	fmt.Println(IsValidCurrency(currency.RUB, secondOfMarch))
 	// Output:
@@ -39,16 +41,40 @@ func TestIsValidCurrency(t *testing.T) {
 		},
 		{
 			name:     "RUB before 1. March",
-			date:     time.Date(2022, time.February, 23, 0, 0, 0, 0, time.Local),
+			date:     time.Date(2022, time.February, 23, 0, 0, 0, 0, Location),
 			currency: currency.RUB,
 			expected: true,
 		},
 		{
 			name:     "RUB after 1. March",
-			date:     time.Date(2022, time.March, 2, 0, 0, 0, 0, time.Local),
+			date:     time.Date(2022, time.March, 2, 0, 0, 0, 0, Location),
 			currency: currency.RUB,
 			expected: false,
 		},
+		{
+			name:     "HRK before EUR adoption",
+			date:     time.Date(2022, time.December, 30, 0, 0, 0, 0, Location),
+			currency: currency.HRK,
+			expected: true,
+		},
+		{
+			name:     "HRK after EUR adoption",
+			date:     time.Date(2023, time.January, 2, 0, 0, 0, 0, Location),
+			currency: currency.HRK,
+			expected: false,
+		},
+		{
+			name:     "TRY before redenomination",
+			date:     time.Date(2004, time.December, 31, 0, 0, 0, 0, Location),
+			currency: currency.TRY,
+			expected: false,
+		},
+		{
+			name:     "currency not published by ECB",
+			date:     time.Now(),
+			currency: currency.AED,
+			expected: false,
+		},
 	}
 
 	for _, test := range tt {
@@ -59,3 +85,115 @@ func TestIsValidCurrency(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckCurrency(t *testing.T) {
+	tt := []struct {
+		name     string
+		date     Date
+		currency currency.Currency
+		expected error
+	}{
+		{name: "valid", date: NewDate(2022, time.March, 25), currency: currency.USD},
+		{name: "unknown", date: NewDate(2022, time.March, 25), currency: currency.AED, expected: InvalidCurrency{"AED"}},
+		{
+			name:     "replaced",
+			date:     NewDate(2009, time.January, 2),
+			currency: currency.SKK,
+			expected: CurrencyNotQuoted{
+				currency: currency.SKK,
+				date:     NewDate(2009, time.January, 2),
+				validity: Validity{First: historyStart, Last: NewDate(2008, time.December, 31), Successor: currency.EUR},
+			},
+		},
+		{
+			name:     "not yet quoted",
+			date:     NewDate(2005, time.June, 30),
+			currency: currency.RON,
+			expected: CurrencyNotQuoted{
+				currency: currency.RON,
+				date:     NewDate(2005, time.June, 30),
+				validity: Validity{First: NewDate(2005, time.July, 1)},
+			},
+		},
+		{
+			name:     "quoted later than history start",
+			date:     NewDate(2010, time.December, 31),
+			currency: currency.ILS,
+			expected: CurrencyNotQuoted{
+				currency: currency.ILS,
+				date:     NewDate(2010, time.December, 31),
+				validity: Validity{First: NewDate(2011, time.January, 3)},
+			},
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkCurrency(test.currency, test.date); err != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+// historyFixture is complete history published by ECB, download it by invoking in package directory:
+//
+//	curl -o testdata/eurofxref-hist.zip https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.zip
+var historyFixture = filepath.Join("testdata", "eurofxref-hist.zip")
+
+func TestValidities_history(t *testing.T) {
+	f, err := os.Open(historyFixture)
+	if os.IsNotExist(err) {
+		t.Skipf("%s not found, download it from ECB to compare validities with published history", historyFixture)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// days are ordered from the latest one, so the first seen date of currency is its last published date
+	published := make(map[currency.Currency]Validity)
+	var latest Date
+	err = DecodeZIPFeed(f, func(day DataXML) error {
+		date, err := day.Date.toDate()
+		if err != nil {
+			return err
+		}
+		if latest.IsZero() {
+			latest = date
+		}
+		for _, rate := range day.Rates {
+			validity, ok := published[currency.Currency(rate.Currency)]
+			if !ok {
+				validity.Last = date
+			}
+			validity.First = date
+			published[currency.Currency(rate.Currency)] = validity
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for code, expected := range published {
+		validity, ok := CurrencyValidity(code)
+		if !ok {
+			t.Errorf("missing validity of %s", code)
+			continue
+		}
+		if validity.First != expected.First {
+			t.Errorf("expecting %s to be first published on %v, got: %v", code, expected.First, validity.First)
+		}
+		if expected.Last == latest {
+			expected.Last = Date{}
+		}
+		if validity.Last.After(latest) {
+			// currency is replaced after fixture was downloaded
+			validity.Last = Date{}
+		}
+		if validity.Last != expected.Last {
+			t.Errorf("expecting %s to be last published on %v, got: %v", code, expected.Last, validity.Last)
+		}
+	}
+}
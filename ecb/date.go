@@ -0,0 +1,80 @@
+package ecb
+
+import (
+	"fmt"
+	"time"
+
+	// ECB time zone must be available even on systems without time zone database.
+	_ "time/tzdata"
+)
+
+// Location is time zone of ECB (Frankfurt am Main, CET/CEST). ECB publishes rates for calendar days in this time zone,
+// therefore any queried time is first converted into this time zone before its calendar day is taken.
+var Location = mustLoadLocation("Europe/Berlin")
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// Date is civil calendar day, independent of time of day and time zone. It is used as key for rates published by ECB.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate creates Date object. Values outside of usual ranges are normalized, eg. 32. January becomes 1. February.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, Location))
+}
+
+// DateOf returns calendar day of t in ECB time zone.
+func DateOf(t time.Time) Date {
+	year, month, day := t.In(Location).Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Time returns midnight of date in ECB time zone.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, Location)
+}
+
+// AddDays returns date which is specified number of days after d. Negative number of days goes back in time.
+func (d Date) AddDays(days int) Date {
+	return NewDate(d.Year, d.Month, d.Day+days)
+}
+
+// Before checks whether d is before other date.
+func (d Date) Before(other Date) bool {
+	if d.Year != other.Year {
+		return d.Year < other.Year
+	}
+	if d.Month != other.Month {
+		return d.Month < other.Month
+	}
+	return d.Day < other.Day
+}
+
+// After checks whether d is after other date.
+func (d Date) After(other Date) bool {
+	return other.Before(d)
+}
+
+// IsZero checks whether d is zero value.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Weekday returns day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.Time().Weekday()
+}
+
+// String formats date using "yyyy-mm-dd" layout.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
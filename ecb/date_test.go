@@ -0,0 +1,82 @@
+package ecb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleDateOf() {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	fmt.Println(DateOf(time.Date(2022, time.March, 25, 23, 30, 0, 0, time.UTC)))
+	fmt.Println(DateOf(time.Date(2022, time.March, 25, 7, 0, 0, 0, tokyo)))
+	// Output:
+	// 2022-03-26
+	// 2022-03-24
+}
+
+func TestDateOf(t *testing.T) {
+	tt := []struct {
+		name     string
+		time     time.Time
+		expected Date
+	}{
+		{
+			name:     "midnight in ECB time zone",
+			time:     time.Date(2022, time.March, 25, 0, 0, 0, 0, Location),
+			expected: Date{2022, time.March, 25},
+		},
+		{
+			name:     "late UTC time is next day in winter",
+			time:     time.Date(2022, time.January, 10, 23, 15, 0, 0, time.UTC),
+			expected: Date{2022, time.January, 11},
+		},
+		{
+			name:     "UTC time before midnight in summer",
+			time:     time.Date(2022, time.July, 10, 21, 59, 0, 0, time.UTC),
+			expected: Date{2022, time.July, 10},
+		},
+		{
+			name:     "UTC time after midnight in summer",
+			time:     time.Date(2022, time.July, 10, 22, 0, 0, 0, time.UTC),
+			expected: Date{2022, time.July, 11},
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			if date := DateOf(test.time); date != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, date)
+			}
+		})
+	}
+}
+
+func TestDate_AddDays(t *testing.T) {
+	date := NewDate(2022, time.February, 27)
+	if next := date.AddDays(2); next != NewDate(2022, time.March, 1) {
+		t.Errorf("expected 2022-03-01, got %v", next)
+	}
+	if previous := date.AddDays(-58); previous != NewDate(2021, time.December, 31) {
+		t.Errorf("expected 2021-12-31, got %v", previous)
+	}
+	// daylight saving time switch must not affect calendar days
+	if next := NewDate(2022, time.March, 26).AddDays(1); next != NewDate(2022, time.March, 27) {
+		t.Errorf("expected 2022-03-27, got %v", next)
+	}
+}
+
+func TestDate_Before(t *testing.T) {
+	date := NewDate(2022, time.March, 25)
+	for _, earlier := range []Date{{2021, time.December, 31}, {2022, time.February, 28}, {2022, time.March, 24}} {
+		if !earlier.Before(date) || date.Before(earlier) {
+			t.Errorf("expected %v to be before %v", earlier, date)
+		}
+		if !date.After(earlier) {
+			t.Errorf("expected %v to be after %v", date, earlier)
+		}
+	}
+	if date.Before(date) || date.After(date) {
+		t.Errorf("date must not be before or after itself")
+	}
+}
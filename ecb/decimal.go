@@ -0,0 +1,24 @@
+package ecb
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// exactFloat returns exact decimal value which f represents. Decimal with at most 15 significant digits round-trips
+// through float64, ie. the shortest decimal representation of parsed value is exactly the parsed decimal. ECB rates
+// (eg. IDR or KRW rates with 7 significant digits) are far below that limit, so exactFloat returns the published rate.
+// The same applies to float64 amounts passed to float API, eg. 0.1 is converted as 1/10 instead of its binary approximation.
+func exactFloat(f float64) (*big.Rat, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// ratFloat returns float64 nearest to r.
+func ratFloat(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
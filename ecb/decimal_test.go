@@ -0,0 +1,44 @@
+package ecb
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestExactFloat(t *testing.T) {
+	tt := []struct {
+		decimal string
+		valid   bool
+	}{
+		{decimal: "1.0983", valid: true},
+		{decimal: "0.1", valid: true},
+		{decimal: "16789.07", valid: true},
+		{decimal: "1433.66", valid: true},
+		{decimal: "12345678.9012345", valid: true},
+		{decimal: "NaN"},
+		{decimal: "+Inf"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.decimal, func(t *testing.T) {
+			f, _ := strconv.ParseFloat(test.decimal, 64)
+			exact, ok := exactFloat(f)
+			if ok != test.valid {
+				t.Fatalf("expecting valid=%v, got: %v", test.valid, ok)
+			}
+			if !test.valid {
+				return
+			}
+			expected, _ := new(big.Rat).SetString(test.decimal)
+			if exact.Cmp(expected) != 0 {
+				t.Errorf("expecting %s, got: %s", test.decimal, exact.FloatString(10))
+			}
+		})
+	}
+
+	if _, ok := exactFloat(math.Inf(-1)); ok {
+		t.Error("expecting -Inf to be invalid")
+	}
+}
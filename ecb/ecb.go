@@ -1,150 +1,731 @@
 /*
 	This package holds everything related to ECB converter. Since ECB updates are rare (once per day), converter client uses caching
-	in order to boost perfomance and avoid necessary http calls. Keep in mind that ECB has data for the last 90 days and accessing data
-	outside of this timeline will result in error.
+	in order to boost perfomance and avoid necessary http calls. ECB publishes rates for the last 90 days as well as complete history
+	starting from 4. January 1999. Converter fetches the smallest feed which covers queried date, so conversions for the latest day
+	only download daily rates, and only conversions older than 90 days require download of complete history.
+
+	ECB publishes rates around 16:00 CET on TARGET business days, so cached rates are refreshed only when newer rates
+	could have been published since they were fetched, see CachePolicy.
+	Cache can be backed by RateStore (eg. MemoryStore, FileStore or custom shared store), so rates fetched by one converter
+	are available to others.
 
 	NOTE: Russian RUB currency is removed from ECB and the last EUR/RUB update was on 1. March 2022.
 */
 package ecb
 
 import (
+	"context"
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/filiptubic/eurex/currency"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// feedDailySpan is number of days for which FeedDaily is tried first.
+	feedDailySpan = 1
+	// feed90DaysSpan is number of days covered by Feed90Days.
+	feed90DaysSpan = 90
+)
+
 type currencyMap map[currency.Currency]float64
 
 // Rates is type used for storing ECB rates.
 // As underlaying data structure it uses hash map for quick access to specific currency rate for certain date.
 // Additionally, it holds information what is to most earliest/oldest date available.
+// Rates returned by ECBConverter are never modified afterwards, so they are safe for concurrent reading.
 type Rates struct {
-	first, last time.Time
-	rates       map[time.Time]currencyMap
+	first, last Date
+	rates       map[Date]currencyMap
+	// fetchedAt is time of the latest fetch of rates, while fetched holds time of fetch for every fetched date.
+	// Dates loaded from RateStore are missing in fetched.
+	fetchedAt time.Time
+	fetched   map[Date]time.Time
 }
 
 // ECBConverter is ECB implementation of Converter interface. It supports rates caching for better perfomance.
+// ECBConverter is safe for concurrent use, and concurrent fetches of the same feed result in a single request to ECB.
 type ECBConverter struct {
-	logger *log.Logger
-	cache  bool
-	cached *Rates
-	client ECBClientInterface
+	logger   *log.Logger
+	cache    bool
+	mu       sync.RWMutex
+	cached   *Rates
+	flights  flightGroup
+	client   ECBClientInterface
+	now      func() time.Time
+	policy   LookupPolicy
+	refresh  *CachePolicy
+	store    RateStore
+	storing  sync.WaitGroup
+	storeMu  sync.Mutex
+	provider string
+	rounding rounding
+	strict   bool
+}
+
+// rounding defines how converted values are rounded, see WithRounding and WithPrecision.
+type rounding struct {
+	enabled   bool
+	mode      currency.RoundingMode
+	places    int
+	hasPlaces bool
+}
+
+// round rounds value converted to currency to.
+func (r rounding) round(value *big.Rat, to currency.Currency) *big.Rat {
+	if !r.enabled {
+		return value
+	}
+	if r.hasPlaces {
+		return currency.Round(value, r.places, r.mode)
+	}
+	return to.Round(value, r.mode)
+}
+
+// Option configures optional ECBConverter behaviour.
+type Option func(c *ECBConverter)
+
+// WithLookupPolicy sets policy used when there are no rates published for queried date. Default is LookupStrict.
+func WithLookupPolicy(policy LookupPolicy) Option {
+	return func(c *ECBConverter) {
+		c.policy = policy
+	}
+}
+
+// WithCachePolicy sets policy which decides when cached rates are fetched again. Default is CachePolicy with DefaultCacheTTL.
+func WithCachePolicy(policy *CachePolicy) Option {
+	return func(c *ECBConverter) {
+		c.refresh = policy
+	}
+}
+
+// WithRateStore sets store which backs cache of converter, see RateStore. Store is used only when caching is enabled,
+// in which case rates held by store are loaded into cache already by New. Fetched rates are written to store in
+// background, call ECBConverter.Flush to wait until they are written.
+func WithRateStore(store RateStore) Option {
+	return func(c *ECBConverter) {
+		c.store = store
+	}
+}
+
+// WithProvider sets name of rates provider reported in ConversionResult. Default is ProviderName.
+func WithProvider(name string) Option {
+	return func(c *ECBConverter) {
+		c.provider = name
+	}
+}
+
+// WithRounding rounds converted values using mode to minor units of target currency (eg. 2 decimal places for EUR
+// and 0 for JPY), unless precision is set by WithPrecision. By default converted values are not rounded.
+func WithRounding(mode currency.RoundingMode) Option {
+	return func(c *ECBConverter) {
+		c.rounding.enabled = true
+		c.rounding.mode = mode
+	}
+}
+
+// WithPrecision rounds converted values to specified number of decimal places instead of minor units of target currency.
+// Unless rounding mode is set by WithRounding, currency.RoundHalfEven is used.
+func WithPrecision(places int) Option {
+	return func(c *ECBConverter) {
+		c.rounding.enabled = true
+		c.rounding.places = places
+		c.rounding.hasPlaces = true
+	}
+}
+
+// WithStrictCurrencies makes converter reject currencies which are not known to be published by ECB, see CurrencyValidity.
+// Parsing of ECB feed fails when feed contains currency which is not registered in currency.Currencies.
+// By default such currencies are kept as they are, so converter keeps working when ECB starts publishing new currency.
+func WithStrictCurrencies() Option {
+	return func(c *ECBConverter) {
+		c.strict = true
+	}
 }
 
 // New creates ECBConverter object.
-func New(client ECBClientInterface, cache bool, logger *log.Logger) *ECBConverter {
+func New(client ECBClientInterface, cache bool, logger *log.Logger, options ...Option) *ECBConverter {
 	if logger == nil {
 		logger = log.New()
 	}
-	return &ECBConverter{client: client, cache: cache, logger: logger}
+	c := &ECBConverter{
+		client:   client,
+		cache:    cache,
+		logger:   logger,
+		now:      time.Now,
+		refresh:  NewCachePolicy(DefaultCacheTTL),
+		provider: ProviderName,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if c.cache && c.store != nil {
+		if err := c.loadStore(); err != nil {
+			c.logger.Warnf("failed to load rates from store: %v", err)
+		}
+	}
+	return c
 }
 
-// newRates makes Rates object from raw ECBResponseData object.
-func (c *ECBConverter) newRates(data *ECBResponseData) (*Rates, error) {
-	rates := &Rates{
-		rates: make(map[time.Time]currencyMap),
+// currentTime returns current time used for choosing which feed to fetch.
+func (c *ECBConverter) currentTime() time.Time {
+	if c.now == nil {
+		return time.Now()
 	}
+	return c.now()
+}
 
-	for _, date := range data.Data {
-		t, err := date.Date.toTime()
-		if err != nil {
-			return nil, err
+// feedFor returns the smallest feed which should contain rates for date.
+func (c *ECBConverter) feedFor(date Date) Feed {
+	today := DateOf(c.currentTime())
+	if date.Before(today.AddDays(-feed90DaysSpan)) {
+		return FeedHistory
+	}
+	if date.Before(today.AddDays(-feedDailySpan)) {
+		return Feed90Days
+	}
+	// daily rates can be merged into cache only when cache contains all rates published before them
+	if cached := c.getCached(); c.cache && cached != nil && cached.last.Before(previousBusinessDay(date)) {
+		return Feed90Days
+	}
+	return FeedDaily
+}
+
+// First returns the earliest date of rates.
+func (r *Rates) First() Date {
+	return r.first
+}
+
+// Last returns the latest date of rates.
+func (r *Rates) Last() Date {
+	return r.last
+}
+
+// Merge creates new Rates which contain all dates from both r and other, so neither of them is modified.
+// When both contain the same date, rates from other are used. The earliest and the latest date are recomputed
+// from merged dates.
+func (r *Rates) Merge(other *Rates) *Rates {
+	merged := &Rates{
+		rates:     make(map[Date]currencyMap, len(r.rates)+len(other.rates)),
+		fetchedAt: r.fetchedAt,
+		fetched:   make(map[Date]time.Time, len(r.fetched)+len(other.fetched)),
+	}
+	for date, rates := range r.rates {
+		merged.rates[date] = rates
+		if fetchedAt, ok := r.fetched[date]; ok {
+			merged.fetched[date] = fetchedAt
+		}
+	}
+	for date, rates := range other.rates {
+		merged.rates[date] = rates
+		if fetchedAt, ok := other.fetched[date]; ok {
+			merged.fetched[date] = fetchedAt
+		} else {
+			delete(merged.fetched, date)
 		}
+	}
+	if other.fetchedAt.After(merged.fetchedAt) {
+		merged.fetchedAt = other.fetchedAt
+	}
 
-		// set earliest date
-		zeroTime := time.Time{}
-		if rates.first == zeroTime {
-			rates.first = t
-		} else if rates.first.After(t) {
-			rates.first = t
+	for date := range merged.rates {
+		if merged.first.IsZero() || date.Before(merged.first) {
+			merged.first = date
+		}
+		if date.After(merged.last) {
+			merged.last = date
 		}
+	}
+	return merged
+}
+
+// setFetchedAt records that all dates of rates were fetched at time t.
+func (r *Rates) setFetchedAt(t time.Time) {
+	r.fetchedAt = t
+	r.fetched = make(map[Date]time.Time, len(r.rates))
+	for date := range r.rates {
+		r.fetched[date] = t
+	}
+}
 
-		// set latest date
-		if rates.last == zeroTime {
-			rates.last = t
-		} else if rates.last.Before(t) {
-			rates.last = t
+// newRates makes Rates object from raw ECBResponseData object.
+func (c *ECBConverter) newRates(data *ECBResponseData) (*Rates, error) {
+	builder := c.newRatesBuilder()
+	for _, day := range data.Data {
+		if err := builder.add(day); err != nil {
+			return nil, err
 		}
+	}
+	return builder.rates, nil
+}
 
-		rates.rates[t] = make(currencyMap)
+// ratesBuilder builds Rates day by day, so rates can be built while feed is being decoded, see DecodeFeed.
+type ratesBuilder struct {
+	converter *ECBConverter
+	rates     *Rates
+	// unknown holds unknown currencies which were already logged
+	unknown map[string]struct{}
+}
 
-		for _, rate := range date.Rates {
-			currency, ok := currency.Currencies[rate.Currency]
-			if !ok {
-				return nil, InvalidCurrency{currency: rate.Currency}
-			}
+func (c *ECBConverter) newRatesBuilder() *ratesBuilder {
+	return &ratesBuilder{
+		converter: c,
+		rates:     &Rates{rates: make(map[Date]currencyMap)},
+		unknown:   make(map[string]struct{}),
+	}
+}
+
+// add adds rates of single day.
+func (b *ratesBuilder) add(day DataXML) error {
+	rates := b.rates
+	t, err := day.Date.toDate()
+	if err != nil {
+		return err
+	}
+
+	// set earliest date
+	if rates.first.IsZero() {
+		rates.first = t
+	} else if rates.first.After(t) {
+		rates.first = t
+	}
+
+	// set latest date
+	if rates.last.IsZero() {
+		rates.last = t
+	} else if rates.last.Before(t) {
+		rates.last = t
+	}
+
+	rates.rates[t] = make(currencyMap, len(day.Rates))
+
+	for _, rate := range day.Rates {
+		currency, ok, err := b.converter.parseCurrency(rate.Currency, b.unknown)
+		if err != nil {
+			return err
+		}
+		if ok {
 			rates.rates[t][currency] = rate.Rate
 		}
 	}
-	return rates, nil
+	return nil
+}
+
+// parseCurrency returns currency of rate published by ECB. Currencies not registered in currency.Currencies are
+// rejected in strict mode, otherwise they are kept when code looks like ISO 4217 code and skipped when it doesn't.
+// Unknown codes are logged only once per feed, tracked by unknown.
+func (c *ECBConverter) parseCurrency(code string, unknown map[string]struct{}) (currency.Currency, bool, error) {
+	if known, ok := currency.Currencies[code]; ok {
+		return known, true, nil
+	}
+	if c.strict {
+		return "", false, InvalidCurrency{currency: code}
+	}
+	_, logged := unknown[code]
+	unknown[code] = struct{}{}
+	if !isCurrencyCode(code) {
+		if !logged {
+			c.logger.Warnf("skipping rates of invalid currency: %s", code)
+		}
+		return "", false, nil
+	}
+	if !logged {
+		c.logger.Warnf("unknown currency in ECB feed: %s", code)
+	}
+	return currency.Currency(code), true, nil
+}
+
+// checkCurrency checks whether currency can be converted on date. In strict mode only currencies known to be published
+// by ECB are accepted, otherwise any currency code is accepted, since ECB might have started publishing it.
+func (c *ECBConverter) checkCurrency(code currency.Currency, date Date) error {
+	err := checkCurrency(code, date)
+	if _, ok := err.(InvalidCurrency); ok && !c.strict && isCurrencyCode(string(code)) {
+		return nil
+	}
+	return err
 }
 
 // GetRates fetches rates via ECBClient if rate for certain date is not found in cache or caching is disabled.
-// When caching is enabled, and cache is present, new data is added to cache only when queried date is not found inside cache.
+// When caching is enabled, and cache is present, new data is added to cache only when queried date is not found inside cache
+// and CachePolicy considers cache stale.
+// The smallest feed which covers date is fetched, see fetch. Fetched rates are merged into cache, so cache only grows.
+// Date is looked up as calendar day in ECB time zone, see DateOf.
 func (c *ECBConverter) GetRates(date time.Time) (*Rates, error) {
-	if c.cache && c.cached != nil && c.cached.rates != nil {
+	return c.GetRatesContext(context.Background(), date)
+}
+
+// GetRatesContext is same as GetRates, but fetching rates via ECBClient is cancelled once ctx is done.
+func (c *ECBConverter) GetRatesContext(ctx context.Context, date time.Time) (*Rates, error) {
+	return c.getRates(ctx, DateOf(date))
+}
+
+// getRates implements GetRatesContext for calendar day.
+func (c *ECBConverter) getRates(ctx context.Context, date Date) (*Rates, error) {
+	if c.cache {
 		// if rates are cached for queried date, just return it, don't make http call
-		if _, ok := c.cached.rates[date]; ok {
-			c.logger.Debugf("using cached rates for date %v", date)
-			return c.cached, nil
+		if cached, ok := c.fromCache(date); ok {
+			return cached, nil
+		}
+		if c.store != nil {
+			if err := c.loadStore(); err != nil {
+				c.logger.Warnf("failed to load rates from store: %v", err)
+			} else if cached, ok := c.fromCache(date); ok {
+				return cached, nil
+			}
 		}
 	}
 
-	// fetch data from ECB
-	data, err := c.client.GetRates()
+	rates, err := c.fetch(ctx, date)
 	if err != nil {
 		return nil, err
 	}
-	rates, err := c.newRates(data)
+	if c.cache {
+		// fetched rates were already merged into cache
+		return c.getCached(), nil
+	}
+
+	return rates, nil
+}
+
+// fromCache returns cached rates if they can be used for looking up date, ie. they either contain date or are not stale.
+func (c *ECBConverter) fromCache(date Date) (*Rates, bool) {
+	cached := c.getCached()
+	if cached == nil || cached.rates == nil {
+		return nil, false
+	}
+	if found, ok := cached.lookup(date, c.policy); ok && found == date {
+		c.logger.Debugf("using cached rates for date %v", date)
+		return cached, true
+	}
+	if !c.cachePolicy().stale(cached, date, c.currentTime()) {
+		c.logger.Debugf("using cached rates for date %v, no newer rates are published", date)
+		return cached, true
+	}
+	return nil, false
+}
+
+// loadStore merges all rates from RateStore which are not cached yet into cache.
+// Dates which store fails to read (eg. due to corrupted file) are skipped, so their rates are fetched from ECB again.
+func (c *ECBConverter) loadStore() error {
+	dates, err := c.store.Dates()
+	if err != nil {
+		return err
+	}
+
+	cached := c.getCached()
+	loaded := &Rates{rates: make(map[Date]currencyMap)}
+	for _, date := range dates {
+		if cached != nil && cached.has(date) {
+			continue
+		}
+		rates, ok, err := c.store.Get(date)
+		if err != nil {
+			c.logger.Warnf("failed to load rates for %v from store: %v", date, err)
+			continue
+		}
+		if ok {
+			loaded.rates[date] = rates
+		}
+	}
+	if len(loaded.rates) == 0 {
+		return nil
+	}
+
+	c.logger.Debugf("loaded rates for %d dates from store", len(loaded.rates))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached == nil {
+		c.cached = &Rates{}
+	}
+	c.cached = c.cached.Merge(loaded)
+	return nil
+}
+
+// cachePolicy returns CachePolicy of converter, falling back to policy with DefaultCacheTTL.
+func (c *ECBConverter) cachePolicy() *CachePolicy {
+	if c.refresh == nil {
+		return NewCachePolicy(DefaultCacheTTL)
+	}
+	return c.refresh
+}
+
+// getCached returns cached rates.
+func (c *ECBConverter) getCached() *Rates {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cached
+}
+
+// setCached merges fetched rates into cache and returns new cached rates. Rates which were not cached before are
+// written to RateStore in background, see Flush. Merging creates new Rates, so rates previously returned from cache
+// are never modified.
+func (c *ECBConverter) setCached(rates *Rates) *Rates {
+	if c.store != nil {
+		cached := c.getCached()
+		batch := make(map[Date]currencyMap)
+		for date, dayRates := range rates.rates {
+			if cached == nil || !cached.has(date) {
+				batch[date] = dayRates
+			}
+		}
+		c.storeBatch(batch)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached != nil {
+		rates = c.cached.Merge(rates)
+	}
+	c.cached = rates
+	return rates
+}
+
+// storeBatch writes rates to RateStore in background, so conversions don't wait for slow stores (eg. FileStore
+// writing thousands of dates of complete history). Batches are written one by one.
+func (c *ECBConverter) storeBatch(batch map[Date]currencyMap) {
+	if len(batch) == 0 {
+		return
+	}
+	c.storing.Add(1)
+	go func() {
+		defer c.storing.Done()
+		c.storeMu.Lock()
+		defer c.storeMu.Unlock()
+		for date, rates := range batch {
+			if err := c.store.Put(date, rates); err != nil {
+				c.logger.Warnf("failed to store rates for %v: %v", date, err)
+			}
+		}
+		c.logger.Debugf("stored rates for %d dates", len(batch))
+	}()
+}
+
+// Flush waits until all fetched rates are written to RateStore. Call it before exiting process, so rates which are
+// still being written are not lost.
+func (c *ECBConverter) Flush() {
+	c.storing.Wait()
+}
+
+// fetchFeed downloads rates of feed. Clients implementing ECBStreamClientInterface stream feed directly into rates,
+// otherwise complete feed is decoded first. Clients which can't fetch feeds always fetch Feed90Days, see fetchesFeeds.
+func (c *ECBConverter) fetchFeed(ctx context.Context, feed Feed) (*Rates, error) {
+	if client, ok := c.client.(ECBStreamClientInterface); ok {
+		builder := c.newRatesBuilder()
+		if err := client.StreamFeedContext(ctx, feed, builder.add); err != nil {
+			return nil, err
+		}
+		return builder.rates, nil
+	}
+
+	var data *ECBResponseData
+	var err error
+	switch client := c.client.(type) {
+	case ECBContextClientInterface:
+		data, err = client.GetFeedContext(ctx, feed)
+	case ECBFeedClientInterface:
+		data, err = client.GetFeed(feed)
+	default:
+		data, err = c.client.GetRates()
+	}
 	if err != nil {
 		return nil, err
 	}
-	if c.cache {
-		c.cached = rates
+	return c.newRates(data)
+}
+
+// fetchesFeeds reports whether client of converter can fetch any Feed, otherwise it only fetches Feed90Days using GetRates.
+func (c *ECBConverter) fetchesFeeds() bool {
+	switch c.client.(type) {
+	case ECBStreamClientInterface, ECBContextClientInterface, ECBFeedClientInterface:
+		return true
 	}
+	return false
+}
 
-	return rates, nil
+// fetch fetches rates from ECB starting with the smallest feed which should cover date.
+// If date turns out to be older than the first date of fetched feed, the next larger feed is fetched.
+// Fetched rates are merged into cache before fetch of feed is finished, so callers arriving later find them in cache.
+func (c *ECBConverter) fetch(ctx context.Context, date Date) (*Rates, error) {
+	feed := c.feedFor(date)
+	if !c.fetchesFeeds() {
+		feed = Feed90Days
+	}
+	for {
+		rates, err := c.flights.do(ctx, feed, func(ctx context.Context) (*Rates, error) {
+			fetchedAt := c.currentTime()
+			rates, err := c.fetchFeed(ctx, feed)
+			if err != nil {
+				return nil, err
+			}
+			rates.setFetchedAt(fetchedAt)
+			if c.cache {
+				c.setCached(rates)
+			}
+			return rates, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		next, ok := feed.next()
+		if !ok || !c.fetchesFeeds() || !date.Before(rates.first) {
+			return rates, nil
+		}
+		c.logger.Debugf("%v is not covered by %s, fetching %s", date, feed, next)
+		feed = next
+	}
 }
 
 // Convert converts specified value from one currency to another for certian date.
 func (c *ECBConverter) Convert(date time.Time, value float64, from, to currency.Currency) (float64, error) {
-	if !IsValidCurrency(from, date) {
-		return -1, InvalidCurrency{string(from)}
+	return c.ConvertContext(context.Background(), date, value, from, to)
+}
+
+// ConvertContext is same as Convert, but fetching rates is cancelled once ctx is done.
+func (c *ECBConverter) ConvertContext(ctx context.Context, date time.Time, value float64, from, to currency.Currency) (float64, error) {
+	converted, _, err := c.ConvertWithDateContext(ctx, date, value, from, to)
+	return converted, err
+}
+
+// ConvertWithDate converts specified value from one currency to another for certian date, same as Convert.
+// Additionally, it returns date of applied rates, which differs from queried date when rates were looked up using LookupPolicy.
+// Date is converted into calendar day in ECB time zone, see DateOf.
+func (c *ECBConverter) ConvertWithDate(t time.Time, value float64, from, to currency.Currency) (float64, Date, error) {
+	return c.ConvertWithDateContext(context.Background(), t, value, from, to)
+}
+
+// ConvertWithDateContext is same as ConvertWithDate, but fetching rates is cancelled once ctx is done.
+func (c *ECBConverter) ConvertWithDateContext(ctx context.Context, t time.Time, value float64, from, to currency.Currency) (float64, Date, error) {
+	result, err := c.ConvertResultContext(ctx, t, value, from, to)
+	if err != nil {
+		return -1, DateOf(t), err
+	}
+	return result.Converted, result.RateDate, nil
+}
+
+// ConvertResult converts specified value from one currency to another for certian date, same as Convert.
+// Additionally, it returns applied rates, their date and source, see ConversionResult.
+func (c *ECBConverter) ConvertResult(t time.Time, value float64, from, to currency.Currency) (ConversionResult, error) {
+	return c.ConvertResultContext(context.Background(), t, value, from, to)
+}
+
+// ConvertResultContext is same as ConvertResult, but fetching rates is cancelled once ctx is done.
+func (c *ECBConverter) ConvertResultContext(ctx context.Context, t time.Time, value float64, from, to currency.Currency) (ConversionResult, error) {
+	exact, ok := exactFloat(value)
+	if !ok {
+		return ConversionResult{}, InvalidAmount{value}
+	}
+	return c.convert(ctx, t, exact, from, to)
+}
+
+// ConvertExact converts specified value from one currency to another for certian date, same as Convert, but using
+// exact decimal arithmetic, so converted value doesn't carry float64 rounding errors.
+func (c *ECBConverter) ConvertExact(t time.Time, value *big.Rat, from, to currency.Currency) (*big.Rat, error) {
+	return c.ConvertExactContext(context.Background(), t, value, from, to)
+}
+
+// ConvertExactContext is same as ConvertExact, but fetching rates is cancelled once ctx is done.
+func (c *ECBConverter) ConvertExactContext(ctx context.Context, t time.Time, value *big.Rat, from, to currency.Currency) (*big.Rat, error) {
+	result, err := c.convert(ctx, t, value, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return result.Exact, nil
+}
+
+// ConvertMoney converts money to another currency for certian date using exact decimal arithmetic, see ConvertExact.
+func (c *ECBConverter) ConvertMoney(t time.Time, money currency.Money, to currency.Currency) (currency.Money, error) {
+	return c.ConvertMoneyContext(context.Background(), t, money, to)
+}
+
+// ConvertMoneyContext is same as ConvertMoney, but fetching rates is cancelled once ctx is done.
+func (c *ECBConverter) ConvertMoneyContext(ctx context.Context, t time.Time, money currency.Money, to currency.Currency) (currency.Money, error) {
+	converted, err := c.ConvertExactContext(ctx, t, money.Amount(), money.Currency(), to)
+	if err != nil {
+		return currency.Money{}, err
+	}
+	return currency.NewMoney(converted, to), nil
+}
+
+// convert holds conversion logic shared by all Convert methods. Value is converted using exact decimal arithmetic and
+// rounded according to converter options, float64 fields of result are nearest to exact values.
+func (c *ECBConverter) convert(ctx context.Context, t time.Time, value *big.Rat, from, to currency.Currency) (ConversionResult, error) {
+	date := DateOf(t)
+	result := ConversionResult{
+		Value:         ratFloat(value),
+		From:          from,
+		To:            to,
+		RequestedDate: date,
+		RateDate:      date,
+		Provider:      c.provider,
 	}
 
-	if !IsValidCurrency(to, date) {
-		return -1, InvalidCurrency{string(to)}
+	if err := c.checkCurrency(from, date); err != nil {
+		return ConversionResult{}, err
+	}
+
+	if err := c.checkCurrency(to, date); err != nil {
+		return ConversionResult{}, err
 	}
 
 	if from == to {
-		return value, nil
+		// no rates are needed, therefore EUR legs are left empty
+		result.Exact = c.rounding.round(new(big.Rat).Set(value), to)
+		result.Converted = ratFloat(result.Exact)
+		result.Rate = 1
+		return result, nil
 	}
 
-	rates, err := c.GetRates(date)
+	rates, err := c.getRates(ctx, date)
 	if err != nil {
-		return -1, err
+		return ConversionResult{}, err
 	}
 
-	if date.Before(rates.first) || date.After(rates.last) {
-		return -1, DateOutOfBound{date, rates.first, rates.last}
+	rateDate, ok := rates.lookup(date, c.policy)
+	if !ok {
+		if date.Before(rates.first) || date.After(rates.last) {
+			return ConversionResult{}, DateOutOfBound{date, rates.first, rates.last}
+		}
+		return ConversionResult{}, RateNotFound{date: date, policy: c.policy}
 	}
+	if rateDate != date {
+		c.logger.Debugf("using rates from %v for %v", rateDate, date)
+	}
+	dayRates := rates.rates[rateDate]
+	result.RateDate = rateDate
+	result.FetchedAt = rates.fetched[rateDate]
 
-	if _, ok := rates.rates[date][from]; from != currency.EUR && !ok {
-		return -1, InvalidCurrency{string(from)}
+	// currency may be valid and still missing on rateDate, eg. when lookup policy falls back to day before it was quoted
+	if _, ok := dayRates[from]; from != currency.EUR && !ok {
+		return ConversionResult{}, notQuoted(from, rateDate)
 	}
 
-	if _, ok := rates.rates[date][to]; to != currency.EUR && !ok {
-		return -1, InvalidCurrency{string(from)}
+	if _, ok := dayRates[to]; to != currency.EUR && !ok {
+		return ConversionResult{}, notQuoted(to, rateDate)
 	}
 
-	if from == currency.EUR {
-		return value * rates.rates[date][to], nil
+	result.FromRate, result.ToRate = 1, 1
+	if from != currency.EUR {
+		result.FromRate = dayRates[from]
+	}
+	if to != currency.EUR {
+		result.ToRate = dayRates[to]
 	}
 
-	if to == currency.EUR {
-		return value / rates.rates[date][from], nil
+	fromRate, ok := exactFloat(result.FromRate)
+	if !ok || fromRate.Sign() <= 0 {
+		return ConversionResult{}, InvalidRate{currency: from, date: rateDate, rate: result.FromRate}
+	}
+	toRate, ok := exactFloat(result.ToRate)
+	if !ok || toRate.Sign() <= 0 {
+		return ConversionResult{}, InvalidRate{currency: to, date: rateDate, rate: result.ToRate}
 	}
 
-	return (value * rates.rates[date][to]) / rates.rates[date][from], nil
+	rate := new(big.Rat).Quo(toRate, fromRate)
+	result.Rate = ratFloat(rate)
+	result.Exact = c.rounding.round(new(big.Rat).Mul(value, rate), to)
+	result.Converted = ratFloat(result.Exact)
+	return result, nil
 }
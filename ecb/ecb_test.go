@@ -1,8 +1,13 @@
 package ecb
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,9 +17,10 @@ import (
 
 func TestECBConverter_newRates(t *testing.T) {
 	tt := []struct {
-		name   string
-		data   *ECBResponseData
-		verify func(m *Rates, err error)
+		name    string
+		data    *ECBResponseData
+		options []Option
+		verify  func(m *Rates, err error)
 	}{
 		{
 			name: "ok map",
@@ -32,11 +38,11 @@ func TestECBConverter_newRates(t *testing.T) {
 				if len(rates.rates) != 2 {
 					t.Errorf("expected only two entry, got: %d", len(rates.rates))
 				}
-				t1 := time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local)
+				t1 := NewDate(2022, 1, 4)
 				if _, ok := rates.rates[t1]; !ok {
 					t.Errorf("missing %v time in map", t1)
 				}
-				t2 := time.Date(2022, 1, 3, 0, 0, 0, 0, time.Local)
+				t2 := NewDate(2022, 1, 3)
 				if _, ok := rates.rates[t1]; !ok {
 					t.Errorf("missing %v time in map", t2)
 				}
@@ -62,12 +68,45 @@ func TestECBConverter_newRates(t *testing.T) {
 					{Date: DateXML("2022-12-12"), Rates: []RateXML{{Currency: "UNKNOWN"}}},
 				},
 			},
+			options: []Option{WithStrictCurrencies()},
 			verify: func(m *Rates, err error) {
 				if _, ok := err.(InvalidCurrency); !ok {
 					t.Errorf("expecting InvalidCurrency got: %v", err)
 				}
 			},
 		},
+		{
+			name: "unknown currency is kept",
+			data: &ECBResponseData{
+				Data: []DataXML{
+					{Date: DateXML("2022-12-12"), Rates: []RateXML{{Currency: "XYZ", Rate: 2}, {Currency: "USD", Rate: 1.5}}},
+				},
+			},
+			verify: func(m *Rates, err error) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rate := m.rates[NewDate(2022, 12, 12)][currency.Currency("XYZ")]; rate != 2 {
+					t.Errorf("expecting XYZ rate 2, got: %v", rate)
+				}
+			},
+		},
+		{
+			name: "invalid currency is skipped",
+			data: &ECBResponseData{
+				Data: []DataXML{
+					{Date: DateXML("2022-12-12"), Rates: []RateXML{{Currency: "UNKNOWN", Rate: 2}, {Currency: "USD", Rate: 1.5}}},
+				},
+			},
+			verify: func(m *Rates, err error) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(m.rates[NewDate(2022, 12, 12)]) != 1 {
+					t.Errorf("expecting only USD rate, got: %v", m.rates[NewDate(2022, 12, 12)])
+				}
+			},
+		},
 		{
 			name: "validate first and last date",
 			data: &ECBResponseData{
@@ -83,19 +122,19 @@ func TestECBConverter_newRates(t *testing.T) {
 				if err != nil {
 					t.Error(err)
 				}
-				if m.first != time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local) {
+				if m.first != NewDate(2022, 1, 1) {
 					t.Errorf("invalid first date in rates: %v", m.first)
 				}
-				if m.last != time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local) {
+				if m.last != NewDate(2023, 1, 1) {
 					t.Errorf("invalid last date in rates: %v", m.last)
 				}
 			},
 		},
 	}
 
-	converter := New(&ECBClientMock{}, false, nil)
 	for _, test := range tt {
 		t.Run(test.name, func(t *testing.T) {
+			converter := New(&ECBClientMock{}, false, nil, test.options...)
 			test.verify(converter.newRates(test.data))
 		})
 	}
@@ -105,13 +144,13 @@ func TestEcbConverter_GetRates(t *testing.T) {
 	tt := []struct {
 		name   string
 		date   time.Time
-		c      ECBConverter
+		c      *ECBConverter
 		verify func(rates *Rates, err error)
 	}{
 		{
 			name: "using cache without cached data",
-			date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local),
-			c: ECBConverter{cache: true, cached: nil, client: &ECBClientMock{
+			date: time.Date(2022, 1, 1, 0, 0, 0, 0, Location),
+			c: &ECBConverter{cache: true, cached: nil, client: &ECBClientMock{
 				GetRatesMock: func() (*ECBResponseData, error) {
 					return &ECBResponseData{
 						Data: []DataXML{
@@ -130,20 +169,20 @@ func TestEcbConverter_GetRates(t *testing.T) {
 				if len(rates.rates) != 1 {
 					t.Errorf("expecting one rate got: %d", len(rates.rates))
 				}
-				if _, ok := rates.rates[time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)]; !ok {
+				if _, ok := rates.rates[NewDate(2022, 1, 1)]; !ok {
 					t.Errorf("missing rate at date '2022-1-1'")
 				}
 			},
 		},
 		{
 			name: "using cache with cached data",
-			date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local),
-			c: ECBConverter{
+			date: time.Date(2022, 1, 1, 0, 0, 0, 0, Location),
+			c: &ECBConverter{
 				cache:  true,
 				logger: log.New(),
 				cached: &Rates{
-					rates: map[time.Time]currencyMap{
-						time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local): {
+					rates: map[Date]currencyMap{
+						NewDate(2022, 1, 1): {
 							currency.USD: 1.5,
 						},
 					}},
@@ -155,20 +194,20 @@ func TestEcbConverter_GetRates(t *testing.T) {
 				if len(rates.rates) != 1 {
 					t.Errorf("expecting one rate got: %d", len(rates.rates))
 				}
-				if _, ok := rates.rates[time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)]; !ok {
+				if _, ok := rates.rates[NewDate(2022, 1, 1)]; !ok {
 					t.Errorf("missing rate at date '2022-1-1'")
 				}
 			},
 		},
 		{
 			name: "using cache with missing new data",
-			date: time.Date(2022, 1, 2, 0, 0, 0, 0, time.Local),
-			c: ECBConverter{
+			date: time.Date(2022, 1, 2, 0, 0, 0, 0, Location),
+			c: &ECBConverter{
 				cache:  true,
 				logger: log.New(),
 				cached: &Rates{
-					rates: map[time.Time]currencyMap{
-						time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local): {
+					rates: map[Date]currencyMap{
+						NewDate(2022, 1, 1): {
 							currency.USD: 1.5,
 						},
 					},
@@ -195,15 +234,15 @@ func TestEcbConverter_GetRates(t *testing.T) {
 				if len(rates.rates) != 2 {
 					t.Errorf("expecting two rates got: %d", len(rates.rates))
 				}
-				if _, ok := rates.rates[time.Date(2022, 1, 2, 0, 0, 0, 0, time.Local)]; !ok {
+				if _, ok := rates.rates[NewDate(2022, 1, 2)]; !ok {
 					t.Errorf("missing rate at date '2022-1-1'")
 				}
 			},
 		},
 		{
 			name: "getting rates without caching",
-			date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local),
-			c: ECBConverter{
+			date: time.Date(2022, 1, 1, 0, 0, 0, 0, Location),
+			c: &ECBConverter{
 				cache: false,
 				client: &ECBClientMock{
 					GetRatesMock: func() (*ECBResponseData, error) {
@@ -225,7 +264,7 @@ func TestEcbConverter_GetRates(t *testing.T) {
 				if len(rates.rates) != 1 {
 					t.Errorf("expecting one rate got: %d", len(rates.rates))
 				}
-				if _, ok := rates.rates[time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)]; !ok {
+				if _, ok := rates.rates[NewDate(2022, 1, 1)]; !ok {
 					t.Errorf("missing rate at date '2022-1-1'")
 				}
 			},
@@ -254,7 +293,7 @@ func ExampleECBConverter_Convert() {
 			}, nil
 		},
 	}
-	date := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.Local)
+	date := time.Date(2022, time.January, 1, 0, 0, 0, 0, Location)
 
 	converter := New(&client, true, log.New())
 	converted, err := converter.Convert(date, 10, currency.USD, currency.PLN)
@@ -286,6 +325,17 @@ func TestECBConverter_Convert(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "currency replaced by EUR",
+			date: time.Date(2023, 1, 2, 0, 0, 0, 0, Location),
+			from: currency.HRK,
+			to:   currency.EUR,
+			verify: func(value float64, err error) {
+				if _, ok := err.(CurrencyNotQuoted); !ok {
+					t.Errorf("expecting CurrencyNotQuoted, got %v", err)
+				}
+			},
+		},
 		{
 			name: "invalid to currency",
 			date: time.Now(),
@@ -314,7 +364,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name:  "converting to EUR",
-			date:  time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local),
+			date:  time.Date(2022, 1, 4, 0, 0, 0, 0, Location),
 			from:  currency.USD,
 			to:    currency.EUR,
 			value: 10,
@@ -339,7 +389,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name:  "converting from EUR",
-			date:  time.Date(2022, 1, 3, 0, 0, 0, 0, time.Local),
+			date:  time.Date(2022, 1, 3, 0, 0, 0, 0, Location),
 			from:  currency.EUR,
 			to:    currency.USD,
 			value: 10,
@@ -372,7 +422,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name:  "converting from USD to JPY",
-			date:  time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local),
+			date:  time.Date(2022, 1, 4, 0, 0, 0, 0, Location),
 			from:  currency.USD,
 			to:    currency.JPY,
 			value: 14,
@@ -397,7 +447,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name: "get rates return error",
-			date: time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local),
+			date: time.Date(2022, 1, 4, 0, 0, 0, 0, Location),
 			from: currency.USD,
 			to:   currency.JPY,
 			GetRatesMock: func() (*ECBResponseData, error) {
@@ -411,7 +461,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name: "making rates hash map return error",
-			date: time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local),
+			date: time.Date(2022, 1, 4, 0, 0, 0, 0, Location),
 			from: currency.USD,
 			to:   currency.JPY,
 			GetRatesMock: func() (*ECBResponseData, error) {
@@ -431,7 +481,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name: "query date too old",
-			date: time.Date(2020, 5, 5, 0, 0, 0, 0, time.Local),
+			date: time.Date(2020, 5, 5, 0, 0, 0, 0, Location),
 			from: currency.USD,
 			to:   currency.JPY,
 			GetRatesMock: func() (*ECBResponseData, error) {
@@ -456,7 +506,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name: "query date too new",
-			date: time.Date(2022, 1, 5, 0, 0, 0, 0, time.Local),
+			date: time.Date(2022, 1, 5, 0, 0, 0, 0, Location),
 			from: currency.USD,
 			to:   currency.JPY,
 			GetRatesMock: func() (*ECBResponseData, error) {
@@ -481,7 +531,7 @@ func TestECBConverter_Convert(t *testing.T) {
 		},
 		{
 			name: "server missing to currency",
-			date: time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local),
+			date: time.Date(2022, 1, 4, 0, 0, 0, 0, Location),
 			from: currency.USD,
 			to:   currency.JPY,
 			GetRatesMock: func() (*ECBResponseData, error) {
@@ -495,14 +545,14 @@ func TestECBConverter_Convert(t *testing.T) {
 				}, nil
 			},
 			verify: func(value float64, err error) {
-				if _, ok := err.(InvalidCurrency); !ok {
-					t.Errorf("expecting InvalidCurrency, got: %v", err)
+				if _, ok := err.(CurrencyNotQuoted); !ok {
+					t.Errorf("expecting CurrencyNotQuoted, got: %v", err)
 				}
 			},
 		},
 		{
 			name: "server missing from currency",
-			date: time.Date(2022, 1, 4, 0, 0, 0, 0, time.Local),
+			date: time.Date(2022, 1, 4, 0, 0, 0, 0, Location),
 			from: currency.USD,
 			to:   currency.JPY,
 			GetRatesMock: func() (*ECBResponseData, error) {
@@ -516,8 +566,8 @@ func TestECBConverter_Convert(t *testing.T) {
 				}, nil
 			},
 			verify: func(value float64, err error) {
-				if _, ok := err.(InvalidCurrency); !ok {
-					t.Errorf("expecting InvalidCurrency, got: %v", err)
+				if _, ok := err.(CurrencyNotQuoted); !ok {
+					t.Errorf("expecting CurrencyNotQuoted, got: %v", err)
 				}
 			},
 		},
@@ -531,3 +581,787 @@ func TestECBConverter_Convert(t *testing.T) {
 		})
 	}
 }
+
+func TestECBConverter_fetch(t *testing.T) {
+	now := time.Date(2022, 3, 25, 12, 0, 0, 0, Location)
+	daily := &ECBResponseData{
+		Data: []DataXML{
+			{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+		},
+	}
+	recent := &ECBResponseData{
+		Data: []DataXML{
+			{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+			{Date: DateXML("2021-12-27"), Rates: []RateXML{{Currency: "USD", Rate: 1.13}}},
+		},
+	}
+	history := &ECBResponseData{
+		Data: []DataXML{
+			{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+			{Date: DateXML("1999-1-4"), Rates: []RateXML{{Currency: "USD", Rate: 1.17}}},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		date     Date
+		expected []Feed
+	}{
+		{
+			name:     "latest date uses daily feed",
+			date:     NewDate(2022, 3, 25),
+			expected: []Feed{FeedDaily},
+		},
+		{
+			name:     "date not covered by daily feed falls back to 90 days feed",
+			date:     NewDate(2022, 3, 24),
+			expected: []Feed{FeedDaily, Feed90Days},
+		},
+		{
+			name:     "recent date uses 90 days feed",
+			date:     NewDate(2022, 3, 1),
+			expected: []Feed{Feed90Days},
+		},
+		{
+			name:     "old date uses history feed",
+			date:     NewDate(2005, 6, 1),
+			expected: []Feed{FeedHistory},
+		},
+		{
+			name:     "date not covered by 90 days feed falls back to history feed",
+			date:     NewDate(2021, 12, 26),
+			expected: []Feed{Feed90Days, FeedHistory},
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			var fetched []Feed
+			c := New(&ECBClientMock{GetFeedMock: func(feed Feed) (*ECBResponseData, error) {
+				fetched = append(fetched, feed)
+				switch feed {
+				case FeedDaily:
+					return daily, nil
+				case FeedHistory:
+					return history, nil
+				}
+				return recent, nil
+			}}, false, log.New())
+			c.now = func() time.Time { return now }
+
+			if _, err := c.fetch(context.Background(), test.date); err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(fetched) != fmt.Sprint(test.expected) {
+				t.Errorf("expecting feeds %v, got: %v", test.expected, fetched)
+			}
+		})
+	}
+}
+
+func TestECBConverter_GetRates_mergeDaily(t *testing.T) {
+	c := New(&ECBClientMock{GetFeedMock: func(feed Feed) (*ECBResponseData, error) {
+		if feed != FeedDaily {
+			t.Fatalf("expecting only daily feed to be fetched, got: %s", feed)
+		}
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}}},
+		}, nil
+	}}, true, log.New())
+	c.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+	c.cached = &Rates{
+		first: NewDate(2022, 3, 23),
+		last:  NewDate(2022, 3, 24),
+		rates: map[Date]currencyMap{
+			NewDate(2022, 3, 23): {currency.USD: 1.2},
+			NewDate(2022, 3, 24): {currency.USD: 1.3},
+		},
+	}
+
+	rates, err := c.GetRates(time.Date(2022, 3, 25, 0, 0, 0, 0, Location))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates != c.cached {
+		t.Errorf("expecting daily rates to be merged into cache")
+	}
+	if len(rates.rates) != 3 {
+		t.Errorf("expecting three dates in cache, got: %d", len(rates.rates))
+	}
+	if rates.first != NewDate(2022, 3, 23) {
+		t.Errorf("invalid first date in rates: %v", rates.first)
+	}
+	if rates.last != NewDate(2022, 3, 25) {
+		t.Errorf("invalid last date in rates: %v", rates.last)
+	}
+}
+
+func TestECBConverter_ConvertWithDate(t *testing.T) {
+	friday := NewDate(2022, 3, 25)
+	saturday := time.Date(2022, 3, 26, 0, 0, 0, 0, Location)
+	monday := NewDate(2022, 3, 28)
+	client := &ECBClientMock{GetRatesMock: func() (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 2}}},
+				{Date: DateXML("2022-3-28"), Rates: []RateXML{{Currency: "USD", Rate: 4}}},
+			},
+		}, nil
+	}}
+
+	tt := []struct {
+		name     string
+		policy   LookupPolicy
+		value    float64
+		rateDate Date
+	}{
+		{name: "previous", policy: LookupPrevious, value: 20, rateDate: friday},
+		{name: "next", policy: LookupNext, value: 40, rateDate: monday},
+		{name: "nearest", policy: LookupNearest, value: 20, rateDate: friday},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			converter := New(client, true, log.New(), WithLookupPolicy(test.policy))
+			value, rateDate, err := converter.ConvertWithDate(saturday, 10, currency.EUR, currency.USD)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != test.value {
+				t.Errorf("expecting value %v, got: %v", test.value, value)
+			}
+			if rateDate != test.rateDate {
+				t.Errorf("expecting rate date %v, got: %v", test.rateDate, rateDate)
+			}
+		})
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		converter := New(client, true, log.New())
+		_, _, err := converter.ConvertWithDate(saturday, 10, currency.EUR, currency.USD)
+		if _, ok := err.(RateNotFound); !ok {
+			t.Errorf("expecting RateNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestECBConverter_ConvertResult(t *testing.T) {
+	saturday := time.Date(2022, 3, 26, 0, 0, 0, 0, Location)
+	fetchedAt := time.Date(2022, 3, 28, 17, 0, 0, 0, Location)
+	client := &ECBClientMock{GetRatesMock: func() (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 2}, {Currency: "PLN", Rate: 4}}},
+			},
+		}, nil
+	}}
+
+	tt := []struct {
+		name     string
+		from, to currency.Currency
+		expected ConversionResult
+	}{
+		{
+			name: "from EUR",
+			from: currency.EUR,
+			to:   currency.USD,
+			expected: ConversionResult{
+				Value: 10, From: currency.EUR, Converted: 20, To: currency.USD,
+				Rate: 2, FromRate: 1, ToRate: 2,
+				RequestedDate: DateOf(saturday), RateDate: NewDate(2022, 3, 25),
+				Provider: "test", FetchedAt: fetchedAt,
+			},
+		},
+		{
+			name: "to EUR",
+			from: currency.PLN,
+			to:   currency.EUR,
+			expected: ConversionResult{
+				Value: 10, From: currency.PLN, Converted: 2.5, To: currency.EUR,
+				Rate: 0.25, FromRate: 4, ToRate: 1,
+				RequestedDate: DateOf(saturday), RateDate: NewDate(2022, 3, 25),
+				Provider: "test", FetchedAt: fetchedAt,
+			},
+		},
+		{
+			name: "cross rate",
+			from: currency.PLN,
+			to:   currency.USD,
+			expected: ConversionResult{
+				Value: 10, From: currency.PLN, Converted: 5, To: currency.USD,
+				Rate: 0.5, FromRate: 4, ToRate: 2,
+				RequestedDate: DateOf(saturday), RateDate: NewDate(2022, 3, 25),
+				Provider: "test", FetchedAt: fetchedAt,
+			},
+		},
+		{
+			name: "same currency",
+			from: currency.USD,
+			to:   currency.USD,
+			expected: ConversionResult{
+				Value: 10, From: currency.USD, Converted: 10, To: currency.USD,
+				Rate: 1, FromRate: 0, ToRate: 0,
+				RequestedDate: DateOf(saturday), RateDate: DateOf(saturday),
+				Provider: "test",
+			},
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			converter := New(client, true, log.New(), WithLookupPolicy(LookupPrevious), WithProvider("test"))
+			converter.now = func() time.Time { return fetchedAt }
+			result, err := converter.ConvertResult(saturday, 10, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Exact == nil || result.Exact.Cmp(new(big.Rat).SetFloat64(test.expected.Converted)) != 0 {
+				t.Errorf("expecting exact value %v, got: %v", test.expected.Converted, result.Exact)
+			}
+			result.Exact = nil
+			if result != test.expected {
+				t.Errorf("expecting %+v, got: %+v", test.expected, result)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		converter := New(client, true, log.New())
+		result, err := converter.ConvertResult(saturday, 10, currency.EUR, currency.USD)
+		if _, ok := err.(DateOutOfBound); !ok {
+			t.Errorf("expecting DateOutOfBound, got: %v", err)
+		}
+		if result != (ConversionResult{}) {
+			t.Errorf("expecting empty result, got: %+v", result)
+		}
+	})
+}
+
+func TestECBConverter_ConvertResult_fetchedAt(t *testing.T) {
+	now := time.Date(2022, 3, 24, 17, 0, 0, 0, Location)
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		// every fetch returns rates published on the current day
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML(DateOf(now).String()), Rates: []RateXML{{Currency: "USD", Rate: 2}}}},
+		}, nil
+	}}
+	store := NewMemoryStore()
+	_ = store.Put(NewDate(2022, 3, 23), currencyMap{currency.USD: 2})
+	converter := New(client, true, log.New(), WithRateStore(store))
+	converter.now = func() time.Time { return now }
+
+	thursday := now
+	if _, err := converter.ConvertResult(thursday, 10, currency.EUR, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	now = time.Date(2022, 3, 25, 17, 0, 0, 0, Location)
+	if _, err := converter.ConvertResult(now, 10, currency.EUR, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name     string
+		date     time.Time
+		expected time.Time
+	}{
+		{name: "earlier fetch", date: NewDate(2022, 3, 24).Time(), expected: thursday},
+		{name: "later fetch", date: NewDate(2022, 3, 25).Time(), expected: now},
+		{name: "loaded from store", date: NewDate(2022, 3, 23).Time()},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := converter.ConvertResult(test.date, 10, currency.EUR, currency.USD)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.FetchedAt.Equal(test.expected) {
+				t.Errorf("expecting rates fetched at %v, got: %v", test.expected, result.FetchedAt)
+			}
+		})
+	}
+}
+
+func ExampleECBConverter_ConvertResult() {
+	client := ECBClientMock{
+		GetRatesMock: func() (*ECBResponseData, error) {
+			return &ECBResponseData{
+				Data: []DataXML{
+					{Date: "2022-3-25", Rates: []RateXML{{Currency: "USD", Rate: 2}, {Currency: "PLN", Rate: 4}}},
+				},
+			}, nil
+		},
+	}
+	date := time.Date(2022, time.March, 26, 0, 0, 0, 0, Location)
+
+	converter := New(&client, true, log.New(), WithLookupPolicy(LookupPrevious))
+	result, err := converter.ConvertResult(date, 10, currency.PLN, currency.USD)
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result.Converted, result.Rate, result.RateDate, result.Provider)
+	// Output: 5 0.5 2022-03-25 ECB
+}
+
+func TestECBConverter_ConvertExact(t *testing.T) {
+	date := time.Date(2022, 3, 25, 0, 0, 0, 0, Location)
+	client := &ECBClientMock{GetRatesMock: func() (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{
+					{Currency: "USD", Rate: 1.0956}, {Currency: "CHF", Rate: 1.0167}, {Currency: "JPY", Rate: 0},
+				}},
+			},
+		}, nil
+	}}
+
+	tt := []struct {
+		name     string
+		value    string
+		from, to currency.Currency
+		expected string
+		err      error
+	}{
+		{name: "from EUR", value: "0.1", from: currency.EUR, to: currency.USD, expected: "0.10956"},
+		{name: "to EUR", value: "1.0956", from: currency.USD, to: currency.EUR, expected: "1"},
+		{name: "cross rate", value: "10.956", from: currency.USD, to: currency.CHF, expected: "10.167"},
+		{name: "same currency", value: "0.3", from: currency.USD, to: currency.USD, expected: "0.3"},
+		{name: "zero rate", value: "1", from: currency.JPY, to: currency.USD, err: InvalidRate{currency.JPY, NewDate(2022, 3, 25), 0}},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			converter := New(client, true, log.New())
+			value, _ := new(big.Rat).SetString(test.value)
+			converted, err := converter.ConvertExact(date, value, test.from, test.to)
+			if err != test.err {
+				t.Fatalf("expecting error %v, got: %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			expected, _ := new(big.Rat).SetString(test.expected)
+			if converted.Cmp(expected) != 0 {
+				t.Errorf("expecting %v, got: %v", expected, converted)
+			}
+		})
+	}
+
+	t.Run("invalid float amount", func(t *testing.T) {
+		converter := New(client, true, log.New())
+		_, err := converter.Convert(date, math.NaN(), currency.EUR, currency.USD)
+		if _, ok := err.(InvalidAmount); !ok {
+			t.Errorf("expecting InvalidAmount, got: %v", err)
+		}
+	})
+}
+
+func ExampleECBConverter_ConvertExact() {
+	client := ECBClientMock{
+		GetRatesMock: func() (*ECBResponseData, error) {
+			return &ECBResponseData{
+				Data: []DataXML{
+					{Date: "2022-3-25", Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+				},
+			}, nil
+		},
+	}
+	date := time.Date(2022, time.March, 25, 0, 0, 0, 0, Location)
+
+	converter := New(&client, true, log.New())
+	converted, err := converter.ConvertExact(date, big.NewRat(1, 10), currency.EUR, currency.USD)
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(converted.FloatString(4))
+	// Output: 0.1100
+}
+
+func TestECBConverter_Convert_rounding(t *testing.T) {
+	date := time.Date(2022, 3, 25, 0, 0, 0, 0, Location)
+	client := &ECBClientMock{GetRatesMock: func() (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{
+					{Currency: "USD", Rate: 1.0956}, {Currency: "CHF", Rate: 1.0165}, {Currency: "JPY", Rate: 133.15},
+				}},
+			},
+		}, nil
+	}}
+
+	tt := []struct {
+		name     string
+		options  []Option
+		value    float64
+		from, to currency.Currency
+		expected float64
+	}{
+		{name: "not rounded", value: 10, from: currency.USD, to: currency.CHF, expected: 9.278021175611537},
+		{name: "minor units", options: []Option{WithRounding(currency.RoundHalfEven)}, value: 10, from: currency.USD, to: currency.CHF, expected: 9.28},
+		{name: "zero minor units", options: []Option{WithRounding(currency.RoundHalfEven)}, value: 10, from: currency.EUR, to: currency.JPY, expected: 1332},
+		{name: "half even", options: []Option{WithRounding(currency.RoundHalfEven), WithPrecision(3)}, value: 1, from: currency.EUR, to: currency.CHF, expected: 1.016},
+		{name: "half up", options: []Option{WithRounding(currency.RoundHalfUp), WithPrecision(3)}, value: 1, from: currency.EUR, to: currency.CHF, expected: 1.017},
+		{name: "down", options: []Option{WithRounding(currency.RoundDown)}, value: 10, from: currency.USD, to: currency.CHF, expected: 9.27},
+		{name: "up", options: []Option{WithRounding(currency.RoundUp)}, value: 10, from: currency.USD, to: currency.CHF, expected: 9.28},
+		{name: "precision", options: []Option{WithPrecision(3)}, value: 10, from: currency.USD, to: currency.CHF, expected: 9.278},
+		{name: "precision and mode", options: []Option{WithPrecision(3), WithRounding(currency.RoundUp)}, value: 10, from: currency.USD, to: currency.CHF, expected: 9.279},
+		{name: "same currency", options: []Option{WithRounding(currency.RoundHalfEven)}, value: 10.125, from: currency.USD, to: currency.USD, expected: 10.12},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			converter := New(client, true, log.New(), test.options...)
+			value, err := converter.Convert(date, test.value, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != test.expected {
+				t.Errorf("expecting %v, got: %v", test.expected, value)
+			}
+		})
+	}
+}
+
+func ExampleECBConverter_ConvertMoney() {
+	client := ECBClientMock{
+		GetRatesMock: func() (*ECBResponseData, error) {
+			return &ECBResponseData{
+				Data: []DataXML{
+					{Date: "2022-3-25", Rates: []RateXML{{Currency: "USD", Rate: 1.0956}, {Currency: "CHF", Rate: 1.0165}}},
+				},
+			}, nil
+		},
+	}
+	date := time.Date(2022, time.March, 25, 0, 0, 0, 0, Location)
+
+	converter := New(&client, true, log.New(), WithRounding(currency.RoundHalfEven))
+	money, err := currency.ParseMoney("10", currency.USD)
+	if err != nil {
+		panic(err)
+	}
+	converted, err := converter.ConvertMoney(date, money, currency.CHF)
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(converted)
+	// Output: 9.28 CHF
+}
+
+func TestECBConverter_Convert_unknownCurrency(t *testing.T) {
+	date := time.Date(2022, 3, 25, 0, 0, 0, 0, Location)
+	client := &ECBClientMock{GetRatesMock: func() (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "XYZ", Rate: 4}, {Currency: "USD", Rate: 2}}},
+			},
+		}, nil
+	}}
+
+	t.Run("lenient", func(t *testing.T) {
+		converter := New(client, true, log.New())
+		value, err := converter.Convert(date, 10, currency.Currency("XYZ"), currency.USD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != 5 {
+			t.Errorf("expecting value 5, got: %v", value)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		converter := New(client, true, log.New(), WithStrictCurrencies())
+		_, err := converter.Convert(date, 10, currency.Currency("XYZ"), currency.USD)
+		if _, ok := err.(InvalidCurrency); !ok {
+			t.Errorf("expecting InvalidCurrency, got: %v", err)
+		}
+	})
+}
+
+func TestECBConverter_Convert_missingCurrency(t *testing.T) {
+	client := &ECBClientMock{GetRatesMock: func() (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "CHF", Rate: 1}}},
+				{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "CHF", Rate: 1}, {Currency: "USD", Rate: 2}}},
+			},
+		}, nil
+	}}
+	converter := New(client, true, log.New())
+	date := NewDate(2022, time.March, 25)
+
+	tests := []struct {
+		name     string
+		currency currency.Currency
+		validity Validity
+	}{
+		{name: "known", currency: currency.USD, validity: validities[currency.USD]},
+		{name: "unknown", currency: currency.Currency("XYZ")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := converter.Convert(date.Time(), 10, currency.EUR, tt.currency)
+			var notQuoted CurrencyNotQuoted
+			if !errors.As(err, &notQuoted) {
+				t.Fatalf("expecting CurrencyNotQuoted, got: %v", err)
+			}
+			if notQuoted.Currency() != tt.currency || notQuoted.Date() != date || notQuoted.Validity() != tt.validity {
+				t.Errorf("expecting %s on %v with %+v, got: %v", tt.currency, date, tt.validity, notQuoted)
+			}
+		})
+	}
+}
+
+// ratesOnlyClient implements only ECBClientInterface, as clients written before feeds were introduced.
+type ratesOnlyClient struct {
+	calls int
+}
+
+func (c *ratesOnlyClient) GetRates() (*ECBResponseData, error) {
+	c.calls++
+	return &ECBResponseData{
+		Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 2}}}},
+	}, nil
+}
+
+func TestECBConverter_Convert_ratesOnlyClient(t *testing.T) {
+	client := &ratesOnlyClient{}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	value, err := converter.Convert(NewDate(2022, time.March, 25).Time(), 10, currency.EUR, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 20 {
+		t.Errorf("expecting 20, got: %v", value)
+	}
+
+	// dates older than 90 days can't be fetched, so no larger feed is requested
+	_, err = converter.Convert(NewDate(2020, time.March, 25).Time(), 10, currency.EUR, currency.USD)
+	if !errors.Is(err, ErrDateOutOfBound) {
+		t.Errorf("expecting DateOutOfBound, got: %v", err)
+	}
+	if client.calls != 2 {
+		t.Errorf("expecting single GetRates call per conversion, got: %d", client.calls)
+	}
+}
+
+func TestECBConverter_ConvertContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		return nil, ctx.Err()
+	}}
+	converter := New(client, true, log.New())
+	_, err := converter.ConvertContext(ctx, time.Now(), 10, currency.EUR, currency.USD)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expecting context.Canceled, got: %v", err)
+	}
+}
+
+func TestECBConverter_ConvertContext_stalledFetch(t *testing.T) {
+	var calls int32
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// the first request stalls until it's cancelled
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 2}}}},
+		}, nil
+	}}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+	date := NewDate(2022, time.March, 25).Time()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	if _, err := converter.ConvertContext(ctx, date, 10, currency.EUR, currency.USD); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expecting context.DeadlineExceeded, got: %v", err)
+	}
+
+	value, err := converter.Convert(date, 10, currency.EUR, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 20 {
+		t.Errorf("expecting 20, got: %v", value)
+	}
+}
+
+func TestECBConverter_Convert_concurrent(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "USD", Rate: 2}}},
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 4}}},
+			},
+		}, nil
+	}}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			date := time.Date(2022, 3, 24+i%2, 0, 0, 0, 0, Location)
+			value, err := converter.Convert(date, 10, currency.EUR, currency.USD)
+			if err != nil {
+				t.Error(err)
+			}
+			if expected := float64(20 + 20*(i%2)); value != expected {
+				t.Errorf("expecting %v, got: %v", expected, value)
+			}
+		}(i)
+	}
+	waitForWaiters(t, &converter.flights, FeedDaily, 20)
+	close(release)
+	wg.Wait()
+
+	// both dates are looked up in daily feed, which is fetched only once
+	if calls != 1 {
+		t.Errorf("expecting single fetch, got: %d", calls)
+	}
+}
+
+func TestECBConverter_GetRates_concurrentMerge(t *testing.T) {
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 4}}}},
+		}, nil
+	}}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+	converter.cached = &Rates{
+		first: NewDate(2022, 3, 24),
+		last:  NewDate(2022, 3, 24),
+		rates: map[Date]currencyMap{NewDate(2022, 3, 24): {currency.USD: 2}},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rates, err := converter.GetRates(time.Date(2022, 3, 24+i%2, 0, 0, 0, 0, Location))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			// reading returned rates must not race with merging of daily rates
+			for date := range rates.rates {
+				_ = rates.rates[date][currency.USD]
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(converter.cached.rates) != 2 {
+		t.Errorf("expecting daily rates merged into cache, got %d dates", len(converter.cached.rates))
+	}
+}
+
+func TestRates_Merge(t *testing.T) {
+	r := &Rates{
+		first: NewDate(2022, 3, 23),
+		last:  NewDate(2022, 3, 24),
+		rates: map[Date]currencyMap{
+			NewDate(2022, 3, 23): {currency.USD: 1.1},
+			NewDate(2022, 3, 24): {currency.USD: 1.2},
+		},
+	}
+	r.setFetchedAt(time.Date(2022, 3, 24, 17, 0, 0, 0, Location))
+	other := &Rates{
+		first: NewDate(2022, 3, 1),
+		last:  NewDate(2022, 3, 24),
+		rates: map[Date]currencyMap{
+			NewDate(2022, 3, 1):  {currency.USD: 1.3},
+			NewDate(2022, 3, 24): {currency.USD: 1.4},
+		},
+	}
+	other.setFetchedAt(time.Date(2022, 3, 25, 9, 0, 0, 0, Location))
+
+	merged := r.Merge(other)
+	if len(merged.rates) != 3 {
+		t.Errorf("expecting three dates, got: %d", len(merged.rates))
+	}
+	if merged.First() != NewDate(2022, 3, 1) {
+		t.Errorf("invalid first date in rates: %v", merged.First())
+	}
+	if merged.Last() != NewDate(2022, 3, 24) {
+		t.Errorf("invalid last date in rates: %v", merged.Last())
+	}
+	if rate := merged.rates[NewDate(2022, 3, 24)][currency.USD]; rate != 1.4 {
+		t.Errorf("expecting rate from merged rates, got: %v", rate)
+	}
+	if merged.fetchedAt != other.fetchedAt {
+		t.Errorf("expecting the latest fetch time, got: %v", merged.fetchedAt)
+	}
+	if fetchedAt := merged.fetched[NewDate(2022, 3, 23)]; fetchedAt != r.fetchedAt {
+		t.Errorf("expecting fetch time of the only rates of date, got: %v", fetchedAt)
+	}
+	if fetchedAt := merged.fetched[NewDate(2022, 3, 24)]; fetchedAt != other.fetchedAt {
+		t.Errorf("expecting fetch time of merged rates, got: %v", fetchedAt)
+	}
+	if len(r.rates) != 2 || r.first != NewDate(2022, 3, 23) {
+		t.Errorf("merge must not modify original rates")
+	}
+
+	if empty := (&Rates{}).Merge(r); empty.First() != r.first || empty.Last() != r.last {
+		t.Errorf("merging into empty rates must keep bounds, got: [%v, %v]", empty.First(), empty.Last())
+	}
+}
+
+func TestECBConverter_GetRates_grows(t *testing.T) {
+	client := &ECBClientMock{GetFeedMock: func(feed Feed) (*ECBResponseData, error) {
+		switch feed {
+		case FeedHistory:
+			return &ECBResponseData{Data: []DataXML{
+				{Date: DateXML("2005-6-1"), Rates: []RateXML{{Currency: "USD", Rate: 1.2}}},
+				{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+			}}, nil
+		case Feed90Days:
+			return &ECBResponseData{Data: []DataXML{
+				{Date: DateXML("2022-3-1"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+				{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+			}}, nil
+		}
+		return &ECBResponseData{Data: []DataXML{
+			{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}},
+		}}, nil
+	}}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	for _, date := range []Date{NewDate(2022, 3, 25), NewDate(2022, 3, 1), NewDate(2005, 6, 1)} {
+		if _, err := converter.GetRates(date.Time()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cached := converter.getCached()
+	if len(cached.rates) != 4 {
+		t.Errorf("expecting four cached dates, got: %d", len(cached.rates))
+	}
+	if cached.First() != NewDate(2005, 6, 1) {
+		t.Errorf("invalid first date in cache: %v", cached.First())
+	}
+	if cached.Last() != NewDate(2022, 3, 25) {
+		t.Errorf("invalid last date in cache: %v", cached.Last())
+	}
+}
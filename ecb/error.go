@@ -1,8 +1,37 @@
 package ecb
 
 import (
+	"errors"
 	"fmt"
-	"time"
+
+	"github.com/filiptubic/eurex/currency"
+)
+
+// Sentinel errors which can be matched using errors.Is, eg. errors.Is(err, ecb.ErrRateNotFound).
+// Use errors.As to access details of matched error, eg. date and lookup policy of RateNotFound.
+var (
+	// ErrCurrencyUnsupported is matched by InvalidCurrency and CurrencyNotQuoted.
+	ErrCurrencyUnsupported = errors.New("currency unsupported")
+	// ErrCurrencyNotQuoted is matched by CurrencyNotQuoted.
+	ErrCurrencyNotQuoted = errors.New("currency not quoted")
+	// ErrInvalidDate is matched by InvalidDateFormat and DateParseError.
+	ErrInvalidDate = errors.New("invalid date")
+	// ErrHTTPStatus is matched by ECBClientError.
+	ErrHTTPStatus = errors.New("unexpected http status")
+	// ErrRequestFailed is matched by RequestError.
+	ErrRequestFailed = errors.New("request failed")
+	// ErrFeedParse is matched by FeedParseError.
+	ErrFeedParse = errors.New("failed to parse feed")
+	// ErrDateOutOfBound is matched by DateOutOfBound.
+	ErrDateOutOfBound = errors.New("date out of bound")
+	// ErrRateNotFound is matched by RateNotFound.
+	ErrRateNotFound = errors.New("rate not found")
+	// ErrCorruptedFile is matched by CorruptedFileError.
+	ErrCorruptedFile = errors.New("corrupted file")
+	// ErrInvalidRate is matched by InvalidRate.
+	ErrInvalidRate = errors.New("invalid rate")
+	// ErrInvalidAmount is matched by InvalidAmount.
+	ErrInvalidAmount = errors.New("invalid amount")
 )
 
 // InvalidCurrency is used when currency is invalid on not registered for specific converter.
@@ -14,6 +43,16 @@ func (e InvalidCurrency) Error() string {
 	return fmt.Sprintf("invalid currency: %s", e.currency)
 }
 
+// Currency returns invalid currency code.
+func (e InvalidCurrency) Currency() string {
+	return e.currency
+}
+
+// Is reports whether target is ErrCurrencyUnsupported.
+func (e InvalidCurrency) Is(target error) bool {
+	return target == ErrCurrencyUnsupported
+}
+
 // InvalidDateFormat is used when date is invalid format (eg. not in "yyyy-dd-mm" layout for ECB).
 type InvalidDateFormat struct {
 	date   string
@@ -24,6 +63,21 @@ func (e InvalidDateFormat) Error() string {
 	return fmt.Sprintf("expected date layout: %s, got %s", e.layout, e.date)
 }
 
+// Date returns date which has invalid format.
+func (e InvalidDateFormat) Date() string {
+	return e.date
+}
+
+// Layout returns expected date layout.
+func (e InvalidDateFormat) Layout() string {
+	return e.layout
+}
+
+// Is reports whether target is ErrInvalidDate.
+func (e InvalidDateFormat) Is(target error) bool {
+	return target == ErrInvalidDate
+}
+
 // DateParseError is used when date parsing failed due malformed year/month/day.
 type DateParseError struct {
 	msg string
@@ -33,6 +87,16 @@ func (e DateParseError) Error() string {
 	return fmt.Sprintf("%s", e.msg)
 }
 
+// Reason returns why date parsing failed.
+func (e DateParseError) Reason() string {
+	return e.msg
+}
+
+// Is reports whether target is ErrInvalidDate.
+func (e DateParseError) Is(target error) bool {
+	return target == ErrInvalidDate
+}
+
 // ECBClientError represents HTTP related errors (eg. 4xx status codes)
 type ECBClientError struct {
 	statusCode int
@@ -42,12 +106,236 @@ func (e ECBClientError) Error() string {
 	return fmt.Sprintf("http error: code=%v", e.statusCode)
 }
 
+// StatusCode returns HTTP status code of ECB response.
+func (e ECBClientError) StatusCode() int {
+	return e.statusCode
+}
+
+// Is reports whether target is ErrHTTPStatus.
+func (e ECBClientError) Is(target error) bool {
+	return target == ErrHTTPStatus
+}
+
+// RequestError is used when request to ECB failed after all attempts, it wraps error of the last attempt
+// (eg. ECBClientError, network error or context.Canceled), so it can be inspected by errors.Is and errors.As.
+type RequestError struct {
+	url      string
+	attempts int
+	err      error
+}
+
+func (e RequestError) Error() string {
+	return fmt.Sprintf("GET %s failed after %d attempt(s): %v", e.url, e.attempts, e.err)
+}
+
+// URL returns requested URL.
+func (e RequestError) URL() string {
+	return e.url
+}
+
+// Attempts returns number of made attempts.
+func (e RequestError) Attempts() int {
+	return e.attempts
+}
+
+// Unwrap returns error of the last attempt.
+func (e RequestError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is ErrRequestFailed.
+func (e RequestError) Is(target error) bool {
+	return target == ErrRequestFailed
+}
+
+// FeedParseError is used when ECB response is not valid ECB feed (eg. HTML error page or truncated XML document).
+type FeedParseError struct {
+	msg string
+}
+
+func (e FeedParseError) Error() string {
+	return fmt.Sprintf("failed to parse ECB feed: %s", e.msg)
+}
+
+// Reason returns why feed parsing failed.
+func (e FeedParseError) Reason() string {
+	return e.msg
+}
+
+// Is reports whether target is ErrFeedParse.
+func (e FeedParseError) Is(target error) bool {
+	return target == ErrFeedParse
+}
+
 // DateOutOfBound is used when querying date is out of possible dates of conversion.
 type DateOutOfBound struct {
-	date        time.Time
-	first, last time.Time
+	date        Date
+	first, last Date
 }
 
 func (e DateOutOfBound) Error() string {
 	return fmt.Sprintf("%v out of date scope: [%v, %v]", e.date, e.first, e.last)
 }
+
+// Date returns queried date.
+func (e DateOutOfBound) Date() Date {
+	return e.date
+}
+
+// First returns the first date of conversion scope.
+func (e DateOutOfBound) First() Date {
+	return e.first
+}
+
+// Last returns the last date of conversion scope.
+func (e DateOutOfBound) Last() Date {
+	return e.last
+}
+
+// Is reports whether target is ErrDateOutOfBound.
+func (e DateOutOfBound) Is(target error) bool {
+	return target == ErrDateOutOfBound
+}
+
+// RateNotFound is used when ECB has not published rates for queried date (eg. weekends and holidays) and
+// LookupPolicy was not able to find rates for another date.
+type RateNotFound struct {
+	date   Date
+	policy LookupPolicy
+}
+
+func (e RateNotFound) Error() string {
+	return fmt.Sprintf("rates not found for %v using %v lookup policy", e.date, e.policy)
+}
+
+// Date returns queried date.
+func (e RateNotFound) Date() Date {
+	return e.date
+}
+
+// Policy returns lookup policy which was used for lookup.
+func (e RateNotFound) Policy() LookupPolicy {
+	return e.policy
+}
+
+// Is reports whether target is ErrRateNotFound.
+func (e RateNotFound) Is(target error) bool {
+	return target == ErrRateNotFound
+}
+
+// CorruptedFileError is used when file with cached rates has invalid content, eg. it was modified or partially written.
+type CorruptedFileError struct {
+	path   string
+	reason string
+}
+
+func (e CorruptedFileError) Error() string {
+	return fmt.Sprintf("corrupted file %s: %s", e.path, e.reason)
+}
+
+// Path returns path of corrupted file.
+func (e CorruptedFileError) Path() string {
+	return e.path
+}
+
+// Reason returns why file is considered corrupted.
+func (e CorruptedFileError) Reason() string {
+	return e.reason
+}
+
+// Is reports whether target is ErrCorruptedFile.
+func (e CorruptedFileError) Is(target error) bool {
+	return target == ErrCorruptedFile
+}
+
+// InvalidRate is used when rate published for currency is not a positive number, so it can't be used for conversion.
+type InvalidRate struct {
+	currency currency.Currency
+	date     Date
+	rate     float64
+}
+
+func (e InvalidRate) Error() string {
+	return fmt.Sprintf("invalid %s rate for %v: %v", e.currency, e.date, e.rate)
+}
+
+// Currency returns currency which rate is invalid.
+func (e InvalidRate) Currency() currency.Currency {
+	return e.currency
+}
+
+// Date returns date of invalid rate.
+func (e InvalidRate) Date() Date {
+	return e.date
+}
+
+// Rate returns invalid rate.
+func (e InvalidRate) Rate() float64 {
+	return e.rate
+}
+
+// Is reports whether target is ErrInvalidRate.
+func (e InvalidRate) Is(target error) bool {
+	return target == ErrInvalidRate
+}
+
+// InvalidAmount is used when converted amount is not a finite number (eg. NaN or infinity).
+type InvalidAmount struct {
+	amount float64
+}
+
+func (e InvalidAmount) Error() string {
+	return fmt.Sprintf("invalid amount: %v", e.amount)
+}
+
+// Amount returns invalid amount.
+func (e InvalidAmount) Amount() float64 {
+	return e.amount
+}
+
+// Is reports whether target is ErrInvalidAmount.
+func (e InvalidAmount) Is(target error) bool {
+	return target == ErrInvalidAmount
+}
+
+// CurrencyNotQuoted is used when ECB doesn't publish rates of currency on queried date, eg. currency was replaced by EUR
+// or rates of applied date miss it.
+type CurrencyNotQuoted struct {
+	currency currency.Currency
+	date     Date
+	validity Validity
+}
+
+func (e CurrencyNotQuoted) Error() string {
+	msg := fmt.Sprintf("%s not quoted on %v", e.currency, e.date)
+	if !e.validity.First.IsZero() {
+		msg += fmt.Sprintf(", quoted since %v", e.validity.First)
+	}
+	if !e.validity.Last.IsZero() {
+		msg += fmt.Sprintf(" until %v", e.validity.Last)
+	}
+	if e.validity.Successor != "" {
+		msg += fmt.Sprintf(", replaced by %s", e.validity.Successor)
+	}
+	return msg
+}
+
+// Currency returns currency which is not quoted.
+func (e CurrencyNotQuoted) Currency() currency.Currency {
+	return e.currency
+}
+
+// Date returns queried date.
+func (e CurrencyNotQuoted) Date() Date {
+	return e.date
+}
+
+// Validity returns period in which currency is quoted.
+func (e CurrencyNotQuoted) Validity() Validity {
+	return e.validity
+}
+
+// Is reports whether target is ErrCurrencyNotQuoted or ErrCurrencyUnsupported.
+func (e CurrencyNotQuoted) Is(target error) bool {
+	return target == ErrCurrencyNotQuoted || target == ErrCurrencyUnsupported
+}
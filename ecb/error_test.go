@@ -1,9 +1,15 @@
 package ecb
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"testing"
 	"time"
+
+	"github.com/filiptubic/eurex/currency"
 )
 
 func ExampleInvalidCurrency_Error() {
@@ -30,11 +36,106 @@ func ExampleECBClientError_Error() {
 	// http error: code=403
 }
 
+func ExampleFeedParseError_Error() {
+	fmt.Println(FeedParseError{msg: "no rates found in Cube element"}.Error())
+	// Output:
+	// failed to parse ECB feed: no rates found in Cube element
+}
+
 func ExampleDateOutOfBound_Error() {
-	date := time.Date(1993, time.January, 1, 0, 0, 0, 0, time.Local)
-	first := time.Date(2002, time.January, 1, 0, 0, 0, 0, time.Local)
-	last := time.Date(2003, time.January, 1, 0, 0, 0, 0, time.Local)
+	date := NewDate(1993, time.January, 1)
+	first := NewDate(2002, time.January, 1)
+	last := NewDate(2003, time.January, 1)
 	fmt.Println(DateOutOfBound{date: date, first: first, last: last}.Error())
 	// Output:
-	// 1993-01-01 00:00:00 +0100 CET out of date scope: [2002-01-01 00:00:00 +0100 CET, 2003-01-01 00:00:00 +0100 CET]
+	// 1993-01-01 out of date scope: [2002-01-01, 2003-01-01]
+}
+
+func ExampleRateNotFound_Error() {
+	date := NewDate(2022, time.April, 16)
+	fmt.Println(RateNotFound{date: date, policy: LookupStrict}.Error())
+	// Output:
+	// rates not found for 2022-04-16 using strict lookup policy
+}
+
+func ExampleCorruptedFileError_Error() {
+	fmt.Println(CorruptedFileError{path: "rates/2022-03-25.json", reason: "checksum mismatch"}.Error())
+	// Output:
+	// corrupted file rates/2022-03-25.json: checksum mismatch
+}
+
+func ExampleInvalidRate_Error() {
+	fmt.Println(InvalidRate{currency: currency.JPY, date: NewDate(2022, time.March, 25), rate: 0}.Error())
+	// Output:
+	// invalid JPY rate for 2022-03-25: 0
+}
+
+func ExampleInvalidAmount_Error() {
+	fmt.Println(InvalidAmount{amount: math.Inf(1)}.Error())
+	// Output:
+	// invalid amount: +Inf
+}
+
+func ExampleCurrencyNotQuoted_Error() {
+	validity, _ := CurrencyValidity(currency.HRK)
+	fmt.Println(CurrencyNotQuoted{currency: currency.HRK, date: NewDate(2023, time.January, 2), validity: validity}.Error())
+	// Output:
+	// HRK not quoted on 2023-01-02, quoted since 2005-04-01 until 2022-12-30, replaced by EUR
+}
+
+func ExampleCurrencyNotQuoted_Error_unknownValidity() {
+	fmt.Println(CurrencyNotQuoted{currency: currency.Currency("XYZ"), date: NewDate(2022, time.March, 25)}.Error())
+	// Output:
+	// XYZ not quoted on 2022-03-25
+}
+
+func ExampleRequestError_Error() {
+	err := RequestError{url: "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml", attempts: 3, err: ECBClientError{statusCode: http.StatusBadGateway}}
+	fmt.Println(err.Error())
+	// Output:
+	// GET https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml failed after 3 attempt(s): http error: code=502
+}
+
+func ExampleRateNotFound_Is() {
+	var err error = RateNotFound{date: NewDate(2022, time.April, 16), policy: LookupStrict}
+	var notFound RateNotFound
+	if errors.As(err, &notFound) {
+		fmt.Println(errors.Is(err, ErrRateNotFound), notFound.Date(), notFound.Policy())
+	}
+	// Output:
+	// true 2022-04-16 strict
+}
+
+func TestErrors_Is(t *testing.T) {
+	tt := []struct {
+		err      error
+		sentinel error
+	}{
+		{err: InvalidCurrency{currency: "INVALID"}, sentinel: ErrCurrencyUnsupported},
+		{err: InvalidDateFormat{layout: "yyyy-dd-mm", date: "1-1-1993"}, sentinel: ErrInvalidDate},
+		{err: DateParseError{msg: "failed to parse year"}, sentinel: ErrInvalidDate},
+		{err: ECBClientError{statusCode: http.StatusForbidden}, sentinel: ErrHTTPStatus},
+		{err: RequestError{err: ECBClientError{statusCode: http.StatusForbidden}}, sentinel: ErrRequestFailed},
+		{err: RequestError{err: ECBClientError{statusCode: http.StatusForbidden}}, sentinel: ErrHTTPStatus},
+		{err: RequestError{err: context.DeadlineExceeded}, sentinel: context.DeadlineExceeded},
+		{err: FeedParseError{msg: "no rates found"}, sentinel: ErrFeedParse},
+		{err: DateOutOfBound{}, sentinel: ErrDateOutOfBound},
+		{err: RateNotFound{}, sentinel: ErrRateNotFound},
+		{err: CorruptedFileError{}, sentinel: ErrCorruptedFile},
+		{err: InvalidRate{}, sentinel: ErrInvalidRate},
+		{err: InvalidAmount{}, sentinel: ErrInvalidAmount},
+		{err: CurrencyNotQuoted{}, sentinel: ErrCurrencyNotQuoted},
+		{err: CurrencyNotQuoted{}, sentinel: ErrCurrencyUnsupported},
+	}
+
+	for _, test := range tt {
+		t.Run(fmt.Sprintf("%T is %v", test.err, test.sentinel), func(t *testing.T) {
+			if !errors.Is(test.err, test.sentinel) {
+				t.Errorf("expecting %T to match %v", test.err, test.sentinel)
+			}
+			if errors.Is(test.err, ErrInvalidAmount) != (test.sentinel == ErrInvalidAmount) {
+				t.Errorf("expecting %T to match only its sentinels", test.err)
+			}
+		})
+	}
 }
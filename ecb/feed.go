@@ -0,0 +1,149 @@
+package ecb
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// gesmesNamespace is XML namespace of envelope of ECB feeds.
+const gesmesNamespace = "http://www.gesmes.org/xml/2002-08-01"
+
+// DecodeFeed decodes ECB feed from r and calls fn for every day of rates as soon as it is decoded, so complete feed
+// is never held in memory, which matters for FeedHistory. Feed is validated the same way as by ParseFeed,
+// and decoding stops at the first error returned by fn.
+func DecodeFeed(r io.Reader, fn func(day DataXML) error) error {
+	decoder := xml.NewDecoder(r)
+	root, err := rootElement(decoder)
+	if err != nil {
+		return err
+	}
+
+	days := 0
+	// depth is number of currently open Cube elements, days are held by Cube elements nested in outer Cube element
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// EOF before end of root element means document is truncated
+			return FeedParseError{msg: err.Error()}
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "Cube" {
+				if err := decoder.Skip(); err != nil {
+					return FeedParseError{msg: err.Error()}
+				}
+				continue
+			}
+			if depth == 0 {
+				depth++
+				continue
+			}
+
+			day, err := decodeDay(decoder, t)
+			if err != nil {
+				return err
+			}
+			if err := validateDay(day, days); err != nil {
+				return err
+			}
+			days++
+			if err := fn(day); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if t.Name == root.Name {
+				if days == 0 {
+					return FeedParseError{msg: "no rates found in Cube element"}
+				}
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+// rootElement reads root element of feed and checks that it is gesmes envelope.
+func rootElement(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, FeedParseError{msg: err.Error()}
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Space != gesmesNamespace || start.Name.Local != "Envelope" {
+				return xml.StartElement{}, FeedParseError{
+					msg: fmt.Sprintf("expected element <Envelope> in name space %s but have <%s>", gesmesNamespace, start.Name.Local),
+				}
+			}
+			return start, nil
+		}
+	}
+}
+
+// decodeDay decodes rates of single day held by start element. Attributes are read directly instead of using
+// xml.Decoder.DecodeElement, which is considerably slower due to reflection.
+func decodeDay(decoder *xml.Decoder, start xml.StartElement) (DataXML, error) {
+	day := DataXML{Date: DateXML(attr(start, "time"))}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return DataXML{}, FeedParseError{msg: err.Error()}
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "Cube" {
+				if err := decoder.Skip(); err != nil {
+					return DataXML{}, FeedParseError{msg: err.Error()}
+				}
+				continue
+			}
+			rate := RateXML{Currency: attr(t, "currency")}
+			if value := attr(t, "rate"); value != "" {
+				rate.Rate, err = strconv.ParseFloat(value, 64)
+				if err != nil {
+					return DataXML{}, FeedParseError{msg: fmt.Sprintf("invalid %s rate for %s: %s", rate.Currency, day.Date, value)}
+				}
+			}
+			day.Rates = append(day.Rates, rate)
+			if err := decoder.Skip(); err != nil {
+				return DataXML{}, FeedParseError{msg: err.Error()}
+			}
+		case xml.EndElement:
+			return day, nil
+		}
+	}
+}
+
+// attr returns value of attribute of element, or empty string when element has no such attribute.
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// validateDay checks that i-th day of feed has date and at least one rate, and that every rate has currency and positive value.
+func validateDay(day DataXML, i int) error {
+	if day.Date == "" {
+		return FeedParseError{msg: fmt.Sprintf("missing time attribute of Cube element %d", i)}
+	}
+	if len(day.Rates) == 0 {
+		return FeedParseError{msg: fmt.Sprintf("no rates found for %s", day.Date)}
+	}
+	for _, rate := range day.Rates {
+		if rate.Currency == "" {
+			return FeedParseError{msg: fmt.Sprintf("missing currency attribute of rate for %s", day.Date)}
+		}
+		if !(rate.Rate > 0) {
+			return FeedParseError{msg: fmt.Sprintf("invalid %s rate for %s: %v", rate.Currency, day.Date, rate.Rate)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package ecb
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	log "github.com/sirupsen/logrus"
+)
+
+// historyFeed generates ECB feed with rates of 40 currencies for days, which resembles FeedHistory for 6000 days.
+func historyFeed(days int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	buf.WriteString(`<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">`)
+	buf.WriteString(`<gesmes:subject>Reference rates</gesmes:subject><Cube>`)
+	codes := make([]currency.Currency, 0, len(validities))
+	for code := range validities {
+		if code != currency.EUR {
+			codes = append(codes, code)
+		}
+	}
+	date := NewDate(2022, time.March, 25)
+	for i := 0; i < days; i++ {
+		fmt.Fprintf(&buf, `<Cube time="%v">`, date)
+		for j, code := range codes {
+			fmt.Fprintf(&buf, `<Cube currency="%s" rate="%v"/>`, code, 1+float64(i+j)/10000)
+		}
+		buf.WriteString(`</Cube>`)
+		date = date.AddDays(-1)
+	}
+	buf.WriteString(`</Cube></gesmes:Envelope>`)
+	return buf.Bytes()
+}
+
+func TestDecodeFeed(t *testing.T) {
+	t.Run("days are emitted in order", func(t *testing.T) {
+		var dates []DateXML
+		err := DecodeFeed(bytes.NewReader(historyFeed(3)), func(day DataXML) error {
+			dates = append(dates, day.Date)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []DateXML{"2022-03-25", "2022-03-24", "2022-03-23"}
+		if fmt.Sprint(dates) != fmt.Sprint(expected) {
+			t.Errorf("expecting %v, got: %v", expected, dates)
+		}
+	})
+
+	t.Run("callback error stops decoding", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := DecodeFeed(bytes.NewReader(historyFeed(3)), func(day DataXML) error {
+			calls++
+			return stop
+		})
+		if err != stop {
+			t.Errorf("expecting callback error, got: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expecting single call, got: %d", calls)
+		}
+	})
+
+	t.Run("truncated feed", func(t *testing.T) {
+		feed := historyFeed(3)
+		err := DecodeFeed(bytes.NewReader(feed[:len(feed)-20]), func(day DataXML) error { return nil })
+		if _, ok := err.(FeedParseError); !ok {
+			t.Errorf("expecting FeedParseError, got: %v", err)
+		}
+	})
+}
+
+func TestECBConverter_fetch_stream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer ts.Close()
+
+	url, _ := url.Parse(ts.URL)
+	client := NewECBClient(url.Scheme, url.Host, NewECBOptions(0, time.Second), log.New())
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	value, err := converter.Convert(NewDate(2022, time.March, 25).Time(), 10, currency.CHF, currency.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 10 / 1.0165; value != expected {
+		t.Errorf("expecting %v, got: %v", expected, value)
+	}
+}
+
+// BenchmarkFeed_unmarshal measures decoding of feed by reading complete body and unmarshaling it before building rates.
+func BenchmarkFeed_unmarshal(b *testing.B) {
+	feed := historyFeed(6000)
+	converter := New(&ECBClientMock{}, false, log.New())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body, err := ioutil.ReadAll(bytes.NewReader(feed))
+		if err != nil {
+			b.Fatal(err)
+		}
+		data := ECBResponseData{}
+		if err := xml.Unmarshal(body, &data); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := converter.newRates(&data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFeed_stream measures decoding of feed by streaming days directly into rates.
+func BenchmarkFeed_stream(b *testing.B) {
+	feed := historyFeed(6000)
+	converter := New(&ECBClientMock{}, false, log.New())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		builder := converter.newRatesBuilder()
+		if err := DecodeFeed(bytes.NewReader(feed), builder.add); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
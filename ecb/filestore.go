@@ -0,0 +1,187 @@
+package ecb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+)
+
+const (
+	// fileStoreExt is extension of files holding rates in FileStore.
+	fileStoreExt = ".json"
+	// fileStoreTmpPattern is pattern of temporary files used for atomic writes in FileStore.
+	fileStoreTmpPattern = ".*.tmp"
+	// fileStoreStaleTmp is age after which temporary file is considered to be left by interrupted write. Writes take
+	// milliseconds, so younger temporary files may belong to writes in progress, eg. by another replica.
+	fileStoreStaleTmp = time.Hour
+)
+
+// fileRecord is content of file holding rates for single date in FileStore.
+type fileRecord struct {
+	Date     string                        `json:"date"`
+	Rates    map[currency.Currency]float64 `json:"rates"`
+	Checksum string                        `json:"checksum"`
+}
+
+// checksum calculates SHA-256 checksum of date and rates of record.
+func (r fileRecord) checksum() (string, error) {
+	// json.Marshal sorts map keys, therefore encoding is deterministic
+	rates, err := json.Marshal(r.Rates)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(r.Date+"\n"), rates...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// FileStore is RateStore which persists rates in a directory, one JSON file per date (eg. 2022-03-25.json), so cached
+// rates survive restart of the process. Directory can be shared between processes, eg. by mounting it to multiple replicas
+// of a service.
+//
+// Each file holds checksum of its rates, and reading file with invalid content or checksum fails with CorruptedFileError.
+// Files are replaced atomically (written to temporary file which is renamed afterwards), so crashed writes never leave
+// partially written file behind.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates FileStore object which keeps rates inside of dir. Directory is created if it doesn't exist,
+// and stale temporary files left by interrupted writes are removed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := removeStaleTmps(dir, time.Now().Add(-fileStoreStaleTmp)); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// removeStaleTmps removes temporary files inside of dir which were last modified before cutoff.
+func removeStaleTmps(dir string, cutoff time.Time) error {
+	tmps, err := filepath.Glob(filepath.Join(dir, fileStoreTmpPattern))
+	if err != nil {
+		return err
+	}
+	for _, tmp := range tmps {
+		info, err := os.Stat(tmp)
+		if errors.Is(err, os.ErrNotExist) {
+			// write was finished or file was removed by another process meanwhile
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(tmp); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// path returns path of file holding rates for date.
+func (s *FileStore) path(date Date) string {
+	return filepath.Join(s.dir, date.String()+fileStoreExt)
+}
+
+// Get reads rates published on date from file. It fails with CorruptedFileError when file content is not valid.
+func (s *FileStore) Get(date Date) (map[currency.Currency]float64, bool, error) {
+	path := s.path(date)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	record := fileRecord{}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false, CorruptedFileError{path: path, reason: err.Error()}
+	}
+	if record.Date != date.String() {
+		return nil, false, CorruptedFileError{path: path, reason: "unexpected date " + record.Date}
+	}
+	checksum, err := record.checksum()
+	if err != nil {
+		return nil, false, err
+	}
+	if checksum != record.Checksum {
+		return nil, false, CorruptedFileError{path: path, reason: "checksum mismatch"}
+	}
+	return record.Rates, true, nil
+}
+
+// Put atomically writes rates published on date to file.
+func (s *FileStore) Put(date Date, rates map[currency.Currency]float64) error {
+	record := fileRecord{Date: date.String(), Rates: rates}
+	checksum, err := record.checksum()
+	if err != nil {
+		return err
+	}
+	record.Checksum = checksum
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(date), data)
+}
+
+// Dates returns all dates for which directory contains file with rates, in ascending order.
+func (s *FileStore) Dates() ([]Date, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]Date, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, fileStoreExt) {
+			continue
+		}
+		date, err := DateXML(strings.TrimSuffix(name, fileStoreExt)).toDate()
+		if err != nil {
+			// skip files which are not created by FileStore
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sortDates(dates)
+	return dates, nil
+}
+
+// writeFileAtomic writes data to temporary file in the same directory as path, which then replaces file at path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), fileStoreTmpPattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
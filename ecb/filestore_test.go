@@ -0,0 +1,189 @@
+package ecb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "rates"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRateStore(t, store)
+}
+
+func TestFileStore_Dates_skipsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = os.WriteFile(filepath.Join(dir, "README.json"), []byte("{}"), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "2022-03-25.txt"), []byte("{}"), 0o644)
+	_ = os.Mkdir(filepath.Join(dir, "2022-03-24.json"), 0o755)
+
+	dates, err := store.Dates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 0 {
+		t.Errorf("expecting no dates, got: %v", dates)
+	}
+}
+
+func TestFileStore_Get_corrupted(t *testing.T) {
+	tt := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "truncated file",
+			content: `{"date":"2022-03-25","rates":{"USD":1.1`,
+		},
+		{
+			name:    "modified rates",
+			content: `{"date":"2022-03-25","rates":{"USD":2},"checksum":"%s"}`,
+		},
+		{
+			name:    "different date",
+			content: `{"date":"2022-03-24","rates":{"USD":1.1},"checksum":"%s"}`,
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			store, err := NewFileStore(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			date := NewDate(2022, 3, 25)
+			checksum, _ := fileRecord{Date: date.String(), Rates: map[currency.Currency]float64{currency.USD: 1.1}}.checksum()
+			content := strings.Replace(test.content, "%s", checksum, 1)
+			if err := os.WriteFile(store.path(date), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := store.Get(date); err == nil {
+				t.Fatal("expecting error")
+			} else if _, ok := err.(CorruptedFileError); !ok {
+				t.Errorf("expecting CorruptedFileError, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewFileStore_removesStaleTemporaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	tmp := filepath.Join(dir, ".2022-03-25.json123.tmp")
+	_ = os.WriteFile(tmp, []byte(`{"date":`), 0o644)
+	stale := time.Now().Add(-fileStoreStaleTmp - time.Minute)
+	_ = os.Chtimes(tmp, stale, stale)
+	// temporary file of write in progress, eg. by another replica sharing directory
+	inProgress := filepath.Join(dir, ".2022-03-24.json456.tmp")
+	_ = os.WriteFile(inProgress, []byte(`{"date":`), 0o644)
+
+	if _, err := NewFileStore(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Errorf("expecting temporary file to be removed, got: %v", err)
+	}
+	if _, err := os.Stat(inProgress); err != nil {
+		t.Errorf("expecting temporary file of write in progress to be kept, got: %v", err)
+	}
+}
+
+func TestFileStore_Put_replacesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := NewDate(2022, 3, 25)
+	for _, rate := range []float64{1.1, 1.2} {
+		if err := store.Put(date, map[currency.Currency]float64{currency.USD: rate}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expecting only rates file in directory, got %d entries", len(entries))
+	}
+	rates, _, err := store.Get(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates[currency.USD] != 1.2 {
+		t.Errorf("expecting replaced rate 1.2, got: %v", rates[currency.USD])
+	}
+}
+
+func TestECBConverter_withFileStore(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		calls++
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "USD", Rate: 2}}},
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 4}}},
+			},
+		}, nil
+	}}
+	now := func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+	newConverter := func() *ECBConverter {
+		store, err := NewFileStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		converter := New(client, true, log.New(), WithRateStore(store))
+		converter.now = now
+		return converter
+	}
+
+	converter := newConverter()
+	if _, err := converter.Convert(now(), 10, currency.EUR, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	converter.Flush()
+
+	// corrupt one of files, its rates are fetched again and file is rewritten
+	store, _ := NewFileStore(dir)
+	if err := os.WriteFile(store.path(NewDate(2022, 3, 25)), []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// restarted converter loads rates from disk
+	restarted := newConverter()
+	if cached := restarted.getCached(); cached == nil || len(cached.rates) != 1 {
+		t.Fatalf("expecting rates loaded at startup, got: %v", cached)
+	}
+	value, err := restarted.Convert(time.Date(2022, 3, 24, 0, 0, 0, 0, Location), 10, currency.EUR, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 20 || calls != 1 {
+		t.Errorf("expecting 20 without fetching, got: %v with %d fetches", value, calls)
+	}
+
+	value, err = restarted.Convert(now(), 10, currency.EUR, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 40 || calls != 2 {
+		t.Errorf("expecting 40 fetched again, got: %v with %d fetches", value, calls)
+	}
+	restarted.Flush()
+	if _, _, err := store.Get(NewDate(2022, 3, 25)); err != nil {
+		t.Errorf("expecting corrupted file to be rewritten, got: %v", err)
+	}
+}
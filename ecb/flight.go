@@ -0,0 +1,114 @@
+package ecb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup deduplicates concurrent fetches of the same feed, so only one request is made and its result is shared
+// between all callers. Zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[Feed]*flightCall
+}
+
+// flightCall is in-flight or finished fetch of a feed.
+type flightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	rates   *Rates
+	err     error
+}
+
+// do executes fn unless fetch of the same feed is already in flight, in which case it waits for its result.
+// fn runs with context which carries values of ctx, but is cancelled only once all callers waiting for it are done,
+// so cancelling one caller (including the one which started fetch) doesn't abort fetch for other callers.
+// The last caller which stops waiting cancels fetch and waits until fn returns, so fetch never outlives its callers.
+func (g *flightGroup) do(ctx context.Context, feed Feed, fn func(ctx context.Context) (*Rates, error)) (*Rates, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[Feed]*flightCall)
+	}
+	call, ok := g.calls[feed]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[feed] = call
+		go g.run(fetchCtx, feed, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		g.mu.Lock()
+		call.waiters--
+		g.mu.Unlock()
+		return call.rates, call.err
+	case <-ctx.Done():
+	}
+
+	g.mu.Lock()
+	call.waiters--
+	last := call.waiters == 0
+	if last && g.calls[feed] == call {
+		// callers arriving from now on start new fetch instead of joining cancelled one
+		delete(g.calls, feed)
+	}
+	g.mu.Unlock()
+	if last {
+		call.cancel()
+		<-call.done
+	}
+	return nil, ctx.Err()
+}
+
+// run executes fn of call and removes call once it's finished, so the next fetch of feed makes new request.
+func (g *flightGroup) run(ctx context.Context, feed Feed, call *flightCall, fn func(ctx context.Context) (*Rates, error)) {
+	call.rates, call.err = fn(ctx)
+	call.cancel()
+
+	g.mu.Lock()
+	if g.calls[feed] == call {
+		delete(g.calls, feed)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
+
+// waiting returns number of callers waiting for fetch of feed.
+func (g *flightGroup) waiting(feed Feed) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call, ok := g.calls[feed]; ok {
+		return call.waiters
+	}
+	return 0
+}
+
+// detachedContext carries values of parent context, but is never done.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (c detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (c detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
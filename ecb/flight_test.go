@@ -0,0 +1,177 @@
+package ecb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters waits until n callers wait for fetch of feed.
+func waitForWaiters(t *testing.T, group *flightGroup, feed Feed, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for group.waiting(feed) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expecting %d callers waiting for %s, got: %d", n, feed, group.waiting(feed))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFlightGroup_do(t *testing.T) {
+	var group flightGroup
+	var calls int32
+	release := make(chan struct{})
+	expected := &Rates{}
+
+	var wg sync.WaitGroup
+	results := make([]*Rates, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rates, err := group.do(context.Background(), Feed90Days, func(ctx context.Context) (*Rates, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return expected, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = rates
+		}(i)
+	}
+	waitForWaiters(t, &group, Feed90Days, len(results))
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expecting single call, got: %d", calls)
+	}
+	for _, rates := range results {
+		if rates != expected {
+			t.Errorf("expecting shared result")
+		}
+	}
+}
+
+func TestFlightGroup_do_differentFeeds(t *testing.T) {
+	var group flightGroup
+	var calls int32
+	var wg sync.WaitGroup
+	for _, feed := range []Feed{FeedDaily, Feed90Days, FeedHistory} {
+		wg.Add(1)
+		go func(feed Feed) {
+			defer wg.Done()
+			_, _ = group.do(context.Background(), feed, func(ctx context.Context) (*Rates, error) {
+				atomic.AddInt32(&calls, 1)
+				return &Rates{}, nil
+			})
+		}(feed)
+	}
+	wg.Wait()
+
+	if calls != 3 {
+		t.Errorf("expecting call per feed, got: %d", calls)
+	}
+}
+
+func TestFlightGroup_do_cancelWaiting(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+
+	go func() {
+		_, _ = group.do(context.Background(), Feed90Days, func(ctx context.Context) (*Rates, error) {
+			close(started)
+			<-release
+			return &Rates{}, nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := group.do(ctx, Feed90Days, func(ctx context.Context) (*Rates, error) {
+		t.Error("in-flight call must not be executed twice")
+		return nil, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expecting context.Canceled, got: %v", err)
+	}
+}
+
+func TestFlightGroup_do_cancelLeader(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+	started := make(chan struct{})
+	expected := &Rates{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := group.do(ctx, Feed90Days, func(ctx context.Context) (*Rates, error) {
+			close(started)
+			<-release
+			return expected, ctx.Err()
+		})
+		leader <- err
+	}()
+	<-started
+
+	follower := make(chan *Rates)
+	go func() {
+		rates, err := group.do(context.Background(), Feed90Days, func(ctx context.Context) (*Rates, error) {
+			t.Error("in-flight call must not be executed twice")
+			return nil, nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		follower <- rates
+	}()
+	waitForWaiters(t, &group, Feed90Days, 2)
+
+	cancel()
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Errorf("expecting context.Canceled, got: %v", err)
+	}
+	close(release)
+	if rates := <-follower; rates != expected {
+		t.Errorf("expecting shared result")
+	}
+}
+
+func TestFlightGroup_do_cancelLastWaiter(t *testing.T) {
+	var group flightGroup
+	cancelled := make(chan struct{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	_, err := group.do(ctx, Feed90Days, func(ctx context.Context) (*Rates, error) {
+		// stalled request which returns only once it's cancelled
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expecting context.DeadlineExceeded, got: %v", err)
+	}
+	select {
+	case <-cancelled:
+	default:
+		t.Error("expecting fetch to be cancelled once its last caller stopped waiting")
+	}
+
+	expected := &Rates{}
+	rates, err := group.do(context.Background(), Feed90Days, func(ctx context.Context) (*Rates, error) {
+		return expected, nil
+	})
+	if err != nil || rates != expected {
+		t.Errorf("expecting new fetch after cancelled one, got: %v, %v", rates, err)
+	}
+}
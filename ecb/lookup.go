@@ -0,0 +1,72 @@
+package ecb
+
+// maxLookupDays is the maximum number of days lookup policies search for published rates.
+// ECB doesn't publish rates on weekends and TARGET holidays, and the longest such gap (eg. Easter) is shorter than a week.
+const maxLookupDays = 7
+
+// LookupPolicy defines which rates are used when ECB has not published rates for queried date (eg. weekends and holidays).
+type LookupPolicy int
+
+const (
+	// LookupStrict uses only rates published on queried date.
+	LookupStrict LookupPolicy = iota
+	// LookupPrevious uses rates of the last business day before queried date.
+	LookupPrevious
+	// LookupNext uses rates of the first business day after queried date.
+	LookupNext
+	// LookupNearest uses rates of the closest business day. When previous and next business day are equally distant, previous one is used.
+	LookupNearest
+)
+
+func (p LookupPolicy) String() string {
+	switch p {
+	case LookupStrict:
+		return "strict"
+	case LookupPrevious:
+		return "previous"
+	case LookupNext:
+		return "next"
+	case LookupNearest:
+		return "nearest"
+	}
+	return "unknown"
+}
+
+// has checks whether rates are published for date.
+func (r *Rates) has(date Date) bool {
+	_, ok := r.rates[date]
+	return ok
+}
+
+// lookup returns date of published rates which should be used for queried date according to policy.
+func (r *Rates) lookup(date Date, policy LookupPolicy) (Date, bool) {
+	if r.has(date) {
+		return date, true
+	}
+
+	for days := 1; days <= maxLookupDays; days++ {
+		previous := date.AddDays(-days)
+		next := date.AddDays(days)
+
+		switch policy {
+		case LookupPrevious:
+			if r.has(previous) {
+				return previous, true
+			}
+		case LookupNext:
+			if r.has(next) {
+				return next, true
+			}
+		case LookupNearest:
+			if r.has(previous) {
+				return previous, true
+			}
+			if r.has(next) {
+				return next, true
+			}
+		default:
+			return Date{}, false
+		}
+	}
+	return Date{}, false
+}
@@ -0,0 +1,87 @@
+package ecb
+
+import (
+	"testing"
+
+	"github.com/filiptubic/eurex/currency"
+)
+
+func TestRates_lookup(t *testing.T) {
+	// Thursday 14. April 2022 is followed by Good Friday, weekend and Easter Monday.
+	thursday := NewDate(2022, 4, 14)
+	tuesday := NewDate(2022, 4, 19)
+	rates := &Rates{
+		first: thursday,
+		last:  tuesday,
+		rates: map[Date]currencyMap{
+			thursday: {currency.USD: 1.08},
+			tuesday:  {currency.USD: 1.07},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		date     Date
+		policy   LookupPolicy
+		expected Date
+		found    bool
+	}{
+		{
+			name:     "strict with published date",
+			date:     thursday,
+			policy:   LookupStrict,
+			expected: thursday,
+			found:    true,
+		},
+		{
+			name:   "strict with holiday",
+			date:   NewDate(2022, 4, 15),
+			policy: LookupStrict,
+		},
+		{
+			name:     "previous with holiday",
+			date:     NewDate(2022, 4, 18),
+			policy:   LookupPrevious,
+			expected: thursday,
+			found:    true,
+		},
+		{
+			name:     "next with holiday",
+			date:     NewDate(2022, 4, 15),
+			policy:   LookupNext,
+			expected: tuesday,
+			found:    true,
+		},
+		{
+			name:     "nearest with closer previous date",
+			date:     NewDate(2022, 4, 16),
+			policy:   LookupNearest,
+			expected: thursday,
+			found:    true,
+		},
+		{
+			name:     "nearest with closer next date",
+			date:     NewDate(2022, 4, 18),
+			policy:   LookupNearest,
+			expected: tuesday,
+			found:    true,
+		},
+		{
+			name:   "previous too far away",
+			date:   NewDate(2022, 5, 19),
+			policy: LookupPrevious,
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			date, found := rates.lookup(test.date, test.policy)
+			if found != test.found {
+				t.Fatalf("expected found=%v, got %v", test.found, found)
+			}
+			if date != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, date)
+			}
+		})
+	}
+}
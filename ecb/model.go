@@ -1,7 +1,9 @@
 package ecb
 
 import (
+	"encoding/xml"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -11,30 +13,36 @@ import (
 // https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml.
 type DateXML string
 
-func (d DateXML) toTime() (time.Time, error) {
+func (d DateXML) toDate() (Date, error) {
 	parts := strings.Split(string(d), "-")
 	if len(parts) != 3 {
-		return time.Time{}, InvalidDateFormat{layout: "yyyy-dd-mm", date: string(d)}
+		return Date{}, InvalidDateFormat{layout: "yyyy-dd-mm", date: string(d)}
 	}
 	year, err := strconv.Atoi(parts[0])
 	if err != nil {
-		return time.Time{}, DateParseError{
+		return Date{}, DateParseError{
 			msg: fmt.Sprintf("failed to parse year from date: %s, err: %v", d, err),
 		}
 	}
 	month, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return time.Time{}, DateParseError{
+		return Date{}, DateParseError{
 			msg: fmt.Sprintf("failed to parse month from date: %s, err: %v", d, err),
 		}
 	}
 	day, err := strconv.Atoi(parts[2])
 	if err != nil {
-		return time.Time{}, DateParseError{
+		return Date{}, DateParseError{
 			msg: fmt.Sprintf("failed to parse day from date: %s, err: %v", d, err),
 		}
 	}
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local), nil
+	date := Date{Year: year, Month: time.Month(month), Day: day}
+	if NewDate(year, time.Month(month), day) != date {
+		return Date{}, DateParseError{
+			msg: fmt.Sprintf("invalid calendar date: %s", d),
+		}
+	}
+	return date, nil
 }
 
 // RateXML is type used for unmarshaling rate XML data from
@@ -54,5 +62,19 @@ type DataXML struct {
 // ECBResponseData is type used for unmarshaling XML data from
 // https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml.
 type ECBResponseData struct {
-	Data []DataXML `xml:"Cube>Cube"`
+	XMLName xml.Name  `xml:"http://www.gesmes.org/xml/2002-08-01 Envelope"`
+	Data    []DataXML `xml:"Cube>Cube"`
+}
+
+// ParseFeed decodes and validates complete ECB feed, see DecodeFeed and FeedParseError.
+func ParseFeed(r io.Reader) (*ECBResponseData, error) {
+	data := &ECBResponseData{XMLName: xml.Name{Space: gesmesNamespace, Local: "Envelope"}}
+	err := DecodeFeed(r, func(day DataXML) error {
+		data.Data = append(data.Data, day)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
 }
@@ -1,20 +1,21 @@
 package ecb
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
 
-func TestDate_toTime(t *testing.T) {
+func TestDateXML_toDate(t *testing.T) {
 	tt := []struct {
 		name   string
 		date   DateXML
-		verify func(value time.Time, err error)
+		verify func(value Date, err error)
 	}{
 		{
 			name: "invalid date format",
 			date: DateXML("12/11"),
-			verify: func(value time.Time, err error) {
+			verify: func(value Date, err error) {
 				if _, ok := err.(InvalidDateFormat); !ok {
 					t.Errorf("expected InvalidDateFormat got: %v", err)
 				}
@@ -23,7 +24,7 @@ func TestDate_toTime(t *testing.T) {
 		{
 			name: "invalid year date",
 			date: "asdf-12-22",
-			verify: func(value time.Time, err error) {
+			verify: func(value Date, err error) {
 				if _, ok := err.(DateParseError); !ok {
 					t.Errorf("expected DateParseError, got: %v", err)
 				}
@@ -32,7 +33,7 @@ func TestDate_toTime(t *testing.T) {
 		{
 			name: "invalid month date",
 			date: "2002-d-22",
-			verify: func(value time.Time, err error) {
+			verify: func(value Date, err error) {
 				if _, ok := err.(DateParseError); !ok {
 					t.Errorf("expected DateParseError, got: %v", err)
 				}
@@ -41,7 +42,16 @@ func TestDate_toTime(t *testing.T) {
 		{
 			name: "invalid day date",
 			date: "2022-21-a",
-			verify: func(value time.Time, err error) {
+			verify: func(value Date, err error) {
+				if _, ok := err.(DateParseError); !ok {
+					t.Errorf("expected DateParseError, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "invalid calendar date",
+			date: "2022-2-30",
+			verify: func(value Date, err error) {
 				if _, ok := err.(DateParseError); !ok {
 					t.Errorf("expected DateParseError, got: %v", err)
 				}
@@ -50,11 +60,11 @@ func TestDate_toTime(t *testing.T) {
 		{
 			name: "ok date",
 			date: "2002-1-1",
-			verify: func(value time.Time, err error) {
+			verify: func(value Date, err error) {
 				if err != nil {
 					t.Errorf("got error: %v", err)
 				}
-				if value != time.Date(2002, 1, 1, 0, 0, 0, 0, time.Local) {
+				if value != NewDate(2002, time.January, 1) {
 					t.Errorf("invalid date: %v", value)
 				}
 			},
@@ -63,7 +73,67 @@ func TestDate_toTime(t *testing.T) {
 
 	for _, test := range tt {
 		t.Run(test.name, func(t *testing.T) {
-			test.verify(test.date.toTime())
+			test.verify(test.date.toDate())
+		})
+	}
+}
+
+func TestParseFeed(t *testing.T) {
+	tt := []struct {
+		name  string
+		feed  string
+		days  int
+		valid bool
+	}{
+		{name: "valid feed", feed: testFeed, days: 1, valid: true},
+		{name: "empty body", feed: ""},
+		{name: "HTML page", feed: "<!DOCTYPE html><html><body>Error</body></html>"},
+		{name: "truncated", feed: testFeed[:len(testFeed)/2]},
+		{
+			name: "missing envelope namespace",
+			feed: `<Envelope><Cube><Cube time="2022-03-25"><Cube currency="USD" rate="1.1"/></Cube></Cube></Envelope>`,
+		},
+		{
+			name: "no days",
+			feed: `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube></Cube></gesmes:Envelope>`,
+		},
+		{
+			name: "missing time",
+			feed: `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube><Cube><Cube currency="USD" rate="1.1"/></Cube></Cube></gesmes:Envelope>`,
+		},
+		{
+			name: "day without rates",
+			feed: `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube><Cube time="2022-03-25"></Cube></Cube></gesmes:Envelope>`,
+		},
+		{
+			name: "missing currency",
+			feed: `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube><Cube time="2022-03-25"><Cube rate="1.1"/></Cube></Cube></gesmes:Envelope>`,
+		},
+		{
+			name: "zero rate",
+			feed: `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube><Cube time="2022-03-25"><Cube currency="USD" rate="0"/></Cube></Cube></gesmes:Envelope>`,
+		},
+		{
+			name: "malformed rate",
+			feed: `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube><Cube time="2022-03-25"><Cube currency="USD" rate="N/A"/></Cube></Cube></gesmes:Envelope>`,
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := ParseFeed(strings.NewReader(test.feed))
+			if !test.valid {
+				if _, ok := err.(FeedParseError); !ok {
+					t.Errorf("expecting FeedParseError, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data.Data) != test.days {
+				t.Errorf("expecting %d days, got: %d", test.days, len(data.Data))
+			}
 		})
 	}
 }
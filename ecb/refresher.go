@@ -0,0 +1,144 @@
+package ecb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRefreshRetry is interval of retrying failed refresh used when Refresher is created with non-positive retry interval.
+const DefaultRefreshRetry = time.Minute
+
+// RefreshStatus reports results of refreshes made by Refresher.
+type RefreshStatus struct {
+	// LastSuccess is time of the last successful refresh.
+	LastSuccess time.Time
+	// LastFailure is time of the last failed refresh.
+	LastFailure time.Time
+	// LastError is error of the last refresh, nil when it succeeded.
+	LastError error
+}
+
+// Refresher prefetches rates into cache of ECBConverter in background, shortly after ECB publishes them, so conversions
+// don't have to wait for rates to be downloaded. When refresh fails, converter keeps using previously cached rates and
+// refresh is retried after retry interval. Converter with disabled caching doesn't keep refreshed rates, so refreshing
+// it only reports whether the latest rates are published.
+type Refresher struct {
+	converter *ECBConverter
+	delay     time.Duration
+	retry     time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	status RefreshStatus
+}
+
+// NewRefresher creates Refresher object which refreshes rates of converter delay after ECB publication time,
+// see PublicationTime, and retries failed refresh every retry interval. Negative delay is treated as zero and
+// non-positive retry interval as DefaultRefreshRetry, so failing refresh never makes requests to ECB in a loop.
+func NewRefresher(converter *ECBConverter, delay, retry time.Duration) *Refresher {
+	if delay < 0 {
+		delay = 0
+	}
+	if retry <= 0 {
+		retry = DefaultRefreshRetry
+	}
+	return &Refresher{converter: converter, delay: delay, retry: retry}
+}
+
+// Start starts refreshing in background until ctx is done or Stop is called. The first refresh is made immediately.
+// Calling Start on already started Refresher has no effect.
+func (r *Refresher) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go r.run(ctx, r.done)
+}
+
+// Stop stops refreshing and waits until ongoing refresh is finished, ie. its fetch is cancelled and no more rates are
+// cached by it. Fetch shared with concurrent conversions of converter keeps running for them, see ECBConverter.
+func (r *Refresher) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Status returns results of refreshes made so far.
+func (r *Refresher) Status() RefreshStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Refresh makes sure that cache of converter holds the latest published rates, see LatestPublication.
+// It fails with RateNotFound when ECB has not published them yet.
+func (r *Refresher) Refresh(ctx context.Context) error {
+	now := r.converter.currentTime()
+	err := r.refresh(ctx, LatestPublication(now))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.LastError = err
+	if err != nil {
+		r.status.LastFailure = now
+		r.converter.logger.Errorf("failed to refresh rates: %v", err)
+	} else {
+		r.status.LastSuccess = now
+	}
+	return err
+}
+
+// refresh fetches rates for date unless they are already cached.
+func (r *Refresher) refresh(ctx context.Context, date Date) error {
+	c := r.converter
+	if cached := c.getCached(); cached != nil && cached.has(date) {
+		return nil
+	}
+
+	// fetched rates are merged into cache by fetch
+	rates, err := c.fetch(ctx, date)
+	if err != nil {
+		return err
+	}
+	if !rates.has(date) {
+		return RateNotFound{date: date, policy: LookupStrict}
+	}
+	c.logger.Debugf("refreshed rates for %v", date)
+	return nil
+}
+
+// run refreshes rates until ctx is done, then closes done channel.
+func (r *Refresher) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		err := r.Refresh(ctx)
+		timer := time.NewTimer(r.next(r.converter.currentTime(), err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// next returns duration until the next refresh, depending on result of the previous one made at time now.
+func (r *Refresher) next(now time.Time, err error) time.Duration {
+	next := PublicationTime(nextBusinessDay(LatestPublication(now))).Add(r.delay)
+	if err != nil && now.Add(r.retry).Before(next) {
+		return r.retry
+	}
+	return next.Sub(now)
+}
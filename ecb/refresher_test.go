@@ -0,0 +1,189 @@
+package ecb
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestRefresher_Refresh(t *testing.T) {
+	var fail atomic.Value
+	fail.Store(false)
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		if fail.Load().(bool) {
+			return nil, errors.New("service unavailable")
+		}
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}}},
+		}, nil
+	}}
+	converter := New(client, true, log.New())
+	now := time.Date(2022, 3, 25, 16, 5, 0, 0, Location)
+	converter.now = func() time.Time { return now }
+	refresher := NewRefresher(converter, time.Minute*5, time.Minute)
+
+	if err := refresher.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if status := refresher.Status(); status.LastSuccess != now || status.LastError != nil {
+		t.Errorf("expecting successful refresh, got: %+v", status)
+	}
+
+	// rates for Monday are not published yet, previous rates are kept
+	fail.Store(true)
+	now = time.Date(2022, 3, 28, 16, 5, 0, 0, Location)
+	if err := refresher.Refresh(context.Background()); err == nil {
+		t.Error("expecting refresh to fail")
+	}
+	status := refresher.Status()
+	if status.LastFailure != now || status.LastError == nil {
+		t.Errorf("expecting failed refresh, got: %+v", status)
+	}
+	if status.LastSuccess != time.Date(2022, 3, 25, 16, 5, 0, 0, Location) {
+		t.Errorf("expecting last success to be kept, got: %v", status.LastSuccess)
+	}
+	if cached := converter.getCached(); cached == nil || !cached.has(NewDate(2022, 3, 25)) {
+		t.Error("expecting previously cached rates to be kept")
+	}
+
+	// ECB is late with publication, so refreshed rates don't contain Monday
+	fail.Store(false)
+	err := refresher.Refresh(context.Background())
+	if _, ok := err.(RateNotFound); !ok {
+		t.Errorf("expecting RateNotFound, got: %v", err)
+	}
+}
+
+func TestRefresher_next(t *testing.T) {
+	refresher := NewRefresher(nil, time.Minute*5, time.Minute*10)
+
+	tt := []struct {
+		name     string
+		now      time.Time
+		err      error
+		expected time.Time
+	}{
+		{
+			name:     "success before publication",
+			now:      time.Date(2022, 3, 24, 10, 0, 0, 0, Location),
+			expected: time.Date(2022, 3, 24, 16, 5, 0, 0, Location),
+		},
+		{
+			name:     "success after publication on Friday",
+			now:      time.Date(2022, 3, 25, 16, 5, 0, 0, Location),
+			expected: time.Date(2022, 3, 28, 16, 5, 0, 0, Location),
+		},
+		{
+			name:     "success before Easter",
+			now:      time.Date(2022, 4, 14, 17, 0, 0, 0, Location),
+			expected: time.Date(2022, 4, 19, 16, 5, 0, 0, Location),
+		},
+		{
+			name:     "failure is retried",
+			now:      time.Date(2022, 3, 25, 16, 5, 0, 0, Location),
+			err:      errors.New("failure"),
+			expected: time.Date(2022, 3, 25, 16, 15, 0, 0, Location),
+		},
+		{
+			name:     "failure right before publication",
+			now:      time.Date(2022, 3, 25, 16, 0, 0, 0, Location).Add(-time.Second),
+			err:      errors.New("failure"),
+			expected: time.Date(2022, 3, 25, 16, 5, 0, 0, Location),
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			if next := test.now.Add(refresher.next(test.now, test.err)); !next.Equal(test.expected) {
+				t.Errorf("expecting next refresh at %v, got %v", test.expected, next)
+			}
+		})
+	}
+}
+
+func TestNewRefresher_intervals(t *testing.T) {
+	tt := []struct {
+		name          string
+		delay, retry  time.Duration
+		expectedDelay time.Duration
+		expectedRetry time.Duration
+	}{
+		{name: "valid", delay: time.Minute * 5, retry: time.Minute * 10, expectedDelay: time.Minute * 5, expectedRetry: time.Minute * 10},
+		{name: "zero retry", delay: time.Minute * 5, expectedDelay: time.Minute * 5, expectedRetry: DefaultRefreshRetry},
+		{name: "negative retry", retry: -time.Minute, expectedRetry: DefaultRefreshRetry},
+		{name: "negative delay", delay: -time.Minute, retry: time.Minute, expectedRetry: time.Minute},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			refresher := NewRefresher(nil, test.delay, test.retry)
+			if refresher.delay != test.expectedDelay || refresher.retry != test.expectedRetry {
+				t.Errorf("expecting delay %v and retry %v, got: %v and %v", test.expectedDelay, test.expectedRetry, refresher.delay, refresher.retry)
+			}
+
+			// failed refresh is never retried immediately
+			now := time.Date(2022, 3, 25, 16, 30, 0, 0, Location)
+			if next := refresher.next(now, errors.New("failure")); next <= 0 {
+				t.Errorf("expecting positive time until retry, got: %v", next)
+			}
+		})
+	}
+}
+
+func TestRefresher_StartStop(t *testing.T) {
+	var calls int32
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		atomic.AddInt32(&calls, 1)
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 1.1}}}},
+		}, nil
+	}}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 16, 5, 0, 0, Location) }
+	refresher := NewRefresher(converter, time.Minute*5, time.Minute)
+
+	refresher.Start(context.Background())
+	refresher.Start(context.Background())
+	deadline := time.Now().Add(time.Second * 5)
+	for refresher.Status().LastSuccess.IsZero() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	refresher.Stop()
+	refresher.Stop()
+
+	if refresher.Status().LastSuccess.IsZero() {
+		t.Error("expecting initial refresh after start")
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expecting single fetch, got: %d", calls)
+	}
+}
+
+func TestRefresher_Stop_waitsForFetch(t *testing.T) {
+	var fetching int32
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		atomic.StoreInt32(&fetching, 1)
+		// stalled request which returns only once it's cancelled
+		<-ctx.Done()
+		atomic.StoreInt32(&fetching, 0)
+		return nil, ctx.Err()
+	}}
+	converter := New(client, true, log.New())
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 16, 5, 0, 0, Location) }
+	refresher := NewRefresher(converter, time.Minute*5, time.Minute)
+
+	refresher.Start(context.Background())
+	waitForWaiters(t, &converter.flights, FeedDaily, 1)
+	refresher.Stop()
+
+	if atomic.LoadInt32(&fetching) != 0 {
+		t.Error("expecting fetch to be finished once Stop returns")
+	}
+	if cached := converter.getCached(); cached != nil {
+		t.Errorf("expecting no cached rates, got: %v", cached.rates)
+	}
+}
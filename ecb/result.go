@@ -0,0 +1,39 @@
+package ecb
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+)
+
+// ProviderName is default name of rates provider reported in ConversionResult.
+const ProviderName = "ECB"
+
+// ConversionResult holds converted value together with provenance of applied rates, eg. for auditing purposes.
+type ConversionResult struct {
+	// Value is converted value in From currency.
+	Value float64
+	// From is currency of Value.
+	From currency.Currency
+	// Converted is value converted to To currency. It is float64 nearest to Exact.
+	Converted float64
+	// Exact is value converted to To currency using exact decimal arithmetic, rounded when converter uses WithRounding or WithPrecision.
+	Exact *big.Rat
+	// To is currency of Converted.
+	To currency.Currency
+	// Rate is effective cross rate applied to Value, ie. price of one unit of From currency in To currency.
+	Rate float64
+	// FromRate is EUR leg of From currency, ie. price of one EUR in From currency.
+	FromRate float64
+	// ToRate is EUR leg of To currency, ie. price of one EUR in To currency.
+	ToRate float64
+	// RequestedDate is queried date.
+	RequestedDate Date
+	// RateDate is date of applied rates, which differs from RequestedDate when rates were looked up using LookupPolicy.
+	RateDate Date
+	// Provider is name of rates provider.
+	Provider string
+	// FetchedAt is time when rates were fetched from provider. It is zero when rates were loaded from RateStore.
+	FetchedAt time.Time
+}
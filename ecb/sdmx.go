@@ -0,0 +1,352 @@
+package ecb
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SDMXHost is host of ECB Data Portal API.
+	SDMXHost = "data-api.ecb.europa.eu"
+	// sdmxPath is path of EXR dataflow, which holds exchange rates.
+	sdmxPath = "service/data/EXR/"
+	// sdmxGenericData is media type of SDMX-ML generic data message.
+	sdmxGenericData = "application/vnd.sdmx.genericdata+xml;version=2.1"
+)
+
+// SDMXFormat defines format in which data is requested from ECB Data Portal API.
+type SDMXFormat int
+
+const (
+	// SDMXFormatCSV requests data as SDMX-CSV, which is the most compact format.
+	SDMXFormatCSV SDMXFormat = iota
+	// SDMXFormatXML requests data as SDMX-ML generic data message.
+	SDMXFormatXML
+)
+
+func (f SDMXFormat) String() string {
+	switch f {
+	case SDMXFormatCSV:
+		return "csv"
+	case SDMXFormatXML:
+		return "xml"
+	}
+	return "unknown"
+}
+
+// SDMXQuery selects series of euro reference rates and period fetched from ECB Data Portal API.
+type SDMXQuery struct {
+	// Currencies are currencies which rates are fetched, rates of all currencies are fetched when empty.
+	Currencies []currency.Currency
+	// Start is the first date of fetched rates, rates are fetched since the first published date when zero.
+	Start Date
+	// End is the last date of fetched rates, rates are fetched until the latest published date when zero.
+	End Date
+	// LastN limits fetched rates to the last N published rates of each currency when positive, eg. 1 for the latest rates.
+	LastN int
+}
+
+// Key returns SDMX key of daily EXR series of euro reference rates of query currencies, eg. D.USD+JPY.EUR.SP00.A.
+func (q SDMXQuery) Key() string {
+	codes := make([]string, len(q.Currencies))
+	for i, c := range q.Currencies {
+		codes[i] = string(c)
+	}
+	return fmt.Sprintf("D.%s.EUR.SP00.A", strings.Join(codes, "+"))
+}
+
+// values returns query parameters which select period of query.
+func (q SDMXQuery) values() url.Values {
+	values := url.Values{}
+	if !q.Start.IsZero() {
+		values.Set("startPeriod", q.Start.String())
+	}
+	if !q.End.IsZero() {
+		values.Set("endPeriod", q.End.String())
+	}
+	if q.LastN > 0 {
+		values.Set("lastNObservations", strconv.Itoa(q.LastN))
+	}
+	return values
+}
+
+// SDMXClient fetches euro reference rates from ECB Data Portal API (https://data.ecb.europa.eu), which unlike static
+// files used by ECBClient allows fetching only needed period and currencies, see SDMXQuery.
+// SDMXClient implements ECBClientInterface, so it can be used by ECBConverter instead of ECBClient. Feeds are then
+// fetched as queries for the latest rates, rates since 90 days ago and all rates, restricted to currencies set by WithCurrencies.
+// FeedDaily holds only the latest day of fetched rates, same as daily feed of ECB.
+type SDMXClient struct {
+	client     *ECBClient
+	format     SDMXFormat
+	currencies []currency.Currency
+	now        func() time.Time
+}
+
+// NewSDMXClient creates new SDMXClient, eg. NewSDMXClient("https", SDMXHost, options, logger).
+// Retries and http client are configured by ECBOptions, except feed format which is not used.
+func NewSDMXClient(scheme, host string, options *ECBOptions, logger *log.Logger) *SDMXClient {
+	return &SDMXClient{
+		client: NewECBClient(scheme, host, options, logger),
+		format: SDMXFormatCSV,
+		now:    time.Now,
+	}
+}
+
+// WithFormat sets format in which data is requested. Default is SDMXFormatCSV.
+func (c *SDMXClient) WithFormat(format SDMXFormat) *SDMXClient {
+	c.format = format
+	return c
+}
+
+// WithCurrencies restricts feeds to rates of currencies. By default rates of all currencies are fetched.
+func (c *SDMXClient) WithCurrencies(currencies ...currency.Currency) *SDMXClient {
+	c.currencies = currencies
+	return c
+}
+
+// GetSeries fetches rates selected by query, see GetSeriesContext.
+func (c *SDMXClient) GetSeries(query SDMXQuery) (*ECBResponseData, error) {
+	return c.GetSeriesContext(context.Background(), query)
+}
+
+// GetSeriesContext fetches rates selected by query. Requests are retried and fail the same way as requests made by ECBClient,
+// and response which is not valid SDMX message results in FeedParseError.
+func (c *SDMXClient) GetSeriesContext(ctx context.Context, query SDMXQuery) (*ECBResponseData, error) {
+	data := &ECBResponseData{}
+	err := c.StreamSeriesContext(ctx, query, func(day DataXML) error {
+		data.Data = append(data.Data, day)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// StreamSeriesContext is same as GetSeriesContext, but instead of returning rates it calls fn for every day of rates.
+func (c *SDMXClient) StreamSeriesContext(ctx context.Context, query SDMXQuery, fn func(day DataXML) error) error {
+	values := query.values()
+	header := http.Header{}
+	decode := DecodeSDMXCSV
+	if c.format == SDMXFormatXML {
+		header.Set("Accept", sdmxGenericData)
+		decode = DecodeSDMXML
+	} else {
+		values.Set("format", "csvdata")
+	}
+
+	u := url.URL{
+		Scheme:   c.client.scheme,
+		Host:     c.client.host,
+		Path:     sdmxPath + query.Key(),
+		RawQuery: values.Encode(),
+	}
+	resp, err := c.client.do(ctx, u, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := decode(resp.Body, fn); err != nil {
+		c.client.logger.Errorf("[GET] %v: %v", u.String(), err)
+		return err
+	}
+	return nil
+}
+
+// GetRates fetches rates for the last 90 days, see GetFeed.
+func (c *SDMXClient) GetRates() (*ECBResponseData, error) {
+	return c.GetFeed(Feed90Days)
+}
+
+// GetFeed fetches rates of specified feed, see GetFeedContext.
+func (c *SDMXClient) GetFeed(feed Feed) (*ECBResponseData, error) {
+	return c.GetFeedContext(context.Background(), feed)
+}
+
+// GetFeedContext fetches rates covered by feed, see SDMXClient.
+func (c *SDMXClient) GetFeedContext(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+	data := &ECBResponseData{}
+	err := c.StreamFeedContext(ctx, feed, func(day DataXML) error {
+		data.Data = append(data.Data, day)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// StreamFeedContext is same as GetFeedContext, but instead of returning rates it calls fn for every day of rates.
+func (c *SDMXClient) StreamFeedContext(ctx context.Context, feed Feed, fn func(day DataXML) error) error {
+	if feed == FeedDaily {
+		// the last rate of discontinued currencies (eg. HRK) is years old, so only the latest day is kept
+		latest, found := fn, false
+		fn = func(day DataXML) error {
+			if found {
+				return nil
+			}
+			found = true
+			return latest(day)
+		}
+	}
+	return c.StreamSeriesContext(ctx, c.feedQuery(feed), fn)
+}
+
+// feedQuery returns query which covers the same period as feed.
+func (c *SDMXClient) feedQuery(feed Feed) SDMXQuery {
+	query := SDMXQuery{Currencies: c.currencies}
+	switch feed {
+	case FeedDaily:
+		query.LastN = 1
+	case Feed90Days:
+		query.Start = DateOf(c.now()).AddDays(-feed90DaysSpan)
+	}
+	return query
+}
+
+// sdmxDays groups rates of SDMX series, which hold rates of single currency, into days.
+type sdmxDays map[DateXML][]RateXML
+
+func (d sdmxDays) add(date, code, value string) error {
+	if value == "" || value == "NaN" {
+		// not published
+		return nil
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return FeedParseError{msg: fmt.Sprintf("invalid %s rate for %s: %s", code, date, value)}
+	}
+	day, err := parseCSVDate(date)
+	if err != nil {
+		return err
+	}
+	d[day] = append(d[day], RateXML{Currency: code, Rate: rate})
+	return nil
+}
+
+// emit calls fn for validated days, starting with the latest one as in ECB feeds.
+func (d sdmxDays) emit(fn func(day DataXML) error) error {
+	if len(d) == 0 {
+		return FeedParseError{msg: "no rates found in SDMX message"}
+	}
+	dates := make([]DateXML, 0, len(d))
+	for date := range d {
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i] > dates[j] })
+
+	for i, date := range dates {
+		day := DataXML{Date: date, Rates: d[date]}
+		if err := validateDay(day, i); err != nil {
+			return err
+		}
+		if err := fn(day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeSDMXCSV decodes SDMX-CSV message with EXR series and calls fn for every day of rates. SDMX-CSV holds single
+// observation per row ordered by series, so rates are grouped into days before the first call of fn.
+func DecodeSDMXCSV(r io.Reader, fn func(day DataXML) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return FeedParseError{msg: fmt.Sprintf("failed to read SDMX-CSV header: %v", err)}
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	required := []string{"CURRENCY", "CURRENCY_DENOM", "TIME_PERIOD", "OBS_VALUE"}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return FeedParseError{msg: fmt.Sprintf("missing %s column in SDMX-CSV header", name)}
+		}
+	}
+
+	days := make(sdmxDays)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return FeedParseError{msg: err.Error()}
+		}
+		if len(record) != len(header) {
+			return FeedParseError{msg: fmt.Sprintf("expected %d SDMX-CSV columns, got %d", len(header), len(record))}
+		}
+		if record[columns["CURRENCY_DENOM"]] != string(currency.EUR) {
+			continue
+		}
+		err = days.add(record[columns["TIME_PERIOD"]], record[columns["CURRENCY"]], record[columns["OBS_VALUE"]])
+		if err != nil {
+			return err
+		}
+	}
+	return days.emit(fn)
+}
+
+// sdmxValue is type used for unmarshaling id and value attributes of SDMX-ML elements.
+type sdmxValue struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// sdmxGenericMessage is type used for unmarshaling SDMX-ML generic data message.
+type sdmxGenericMessage struct {
+	XMLName xml.Name `xml:"GenericData"`
+	Series  []struct {
+		Key []sdmxValue `xml:"SeriesKey>Value"`
+		Obs []struct {
+			Date  sdmxValue `xml:"ObsDimension"`
+			Value sdmxValue `xml:"ObsValue"`
+		} `xml:"Obs"`
+	} `xml:"DataSet>Series"`
+}
+
+// DecodeSDMXML decodes SDMX-ML generic data message with EXR series and calls fn for every day of rates.
+// Rates are grouped into days before the first call of fn, see DecodeSDMXCSV.
+func DecodeSDMXML(r io.Reader, fn func(day DataXML) error) error {
+	message := sdmxGenericMessage{}
+	if err := xml.NewDecoder(r).Decode(&message); err != nil {
+		return FeedParseError{msg: err.Error()}
+	}
+
+	days := make(sdmxDays)
+	for _, series := range message.Series {
+		key := make(map[string]string, len(series.Key))
+		for _, value := range series.Key {
+			key[value.ID] = value.Value
+		}
+		if key["CURRENCY"] == "" {
+			return FeedParseError{msg: "missing CURRENCY in SDMX-ML series key"}
+		}
+		if key["CURRENCY_DENOM"] != string(currency.EUR) {
+			continue
+		}
+		for _, obs := range series.Obs {
+			if err := days.add(obs.Date.Value, key["CURRENCY"], obs.Value.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return days.emit(fn)
+}
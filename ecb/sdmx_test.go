@@ -0,0 +1,247 @@
+package ecb
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	log "github.com/sirupsen/logrus"
+)
+
+// testSDMXCSV resembles SDMX-CSV message served by ECB Data Portal API.
+const testSDMXCSV = `KEY,FREQ,CURRENCY,CURRENCY_DENOM,EXR_TYPE,EXR_SUFFIX,TIME_PERIOD,OBS_VALUE,OBS_STATUS
+EXR.D.CHF.EUR.SP00.A,D,CHF,EUR,SP00,A,2022-03-24,1.0206,A
+EXR.D.CHF.EUR.SP00.A,D,CHF,EUR,SP00,A,2022-03-25,1.0165,A
+EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2022-03-24,1.0978,A
+EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2022-03-25,1.0983,A
+`
+
+// testSDMXML resembles SDMX-ML generic data message served by ECB Data Portal API.
+const testSDMXML = `<?xml version="1.0" encoding="UTF-8"?>
+<message:GenericData xmlns:message="http://www.sdmx.org/resources/sdmxml/schemas/v2_1/message" xmlns:generic="http://www.sdmx.org/resources/sdmxml/schemas/v2_1/data/generic">
+<message:Header><message:ID>test</message:ID></message:Header>
+<message:DataSet>
+<generic:Series>
+<generic:SeriesKey>
+<generic:Value id="FREQ" value="D"/><generic:Value id="CURRENCY" value="CHF"/><generic:Value id="CURRENCY_DENOM" value="EUR"/>
+<generic:Value id="EXR_TYPE" value="SP00"/><generic:Value id="EXR_SUFFIX" value="A"/>
+</generic:SeriesKey>
+<generic:Obs><generic:ObsDimension value="2022-03-24"/><generic:ObsValue value="1.0206"/></generic:Obs>
+<generic:Obs><generic:ObsDimension value="2022-03-25"/><generic:ObsValue value="1.0165"/></generic:Obs>
+</generic:Series>
+<generic:Series>
+<generic:SeriesKey>
+<generic:Value id="FREQ" value="D"/><generic:Value id="CURRENCY" value="USD"/><generic:Value id="CURRENCY_DENOM" value="EUR"/>
+<generic:Value id="EXR_TYPE" value="SP00"/><generic:Value id="EXR_SUFFIX" value="A"/>
+</generic:SeriesKey>
+<generic:Obs><generic:ObsDimension value="2022-03-24"/><generic:ObsValue value="1.0978"/></generic:Obs>
+<generic:Obs><generic:ObsDimension value="2022-03-25"/><generic:ObsValue value="1.0983"/></generic:Obs>
+</generic:Series>
+</message:DataSet>
+</message:GenericData>
+`
+
+var testSDMXDays = []DataXML{
+	{Date: "2022-03-25", Rates: []RateXML{{Currency: "CHF", Rate: 1.0165}, {Currency: "USD", Rate: 1.0983}}},
+	{Date: "2022-03-24", Rates: []RateXML{{Currency: "CHF", Rate: 1.0206}, {Currency: "USD", Rate: 1.0978}}},
+}
+
+func TestSDMXQuery_Key(t *testing.T) {
+	tt := []struct {
+		currencies []currency.Currency
+		expected   string
+	}{
+		{expected: "D..EUR.SP00.A"},
+		{currencies: []currency.Currency{currency.USD}, expected: "D.USD.EUR.SP00.A"},
+		{currencies: []currency.Currency{currency.USD, currency.JPY}, expected: "D.USD+JPY.EUR.SP00.A"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.expected, func(t *testing.T) {
+			if key := (SDMXQuery{Currencies: test.currencies}).Key(); key != test.expected {
+				t.Errorf("expecting %s, got: %s", test.expected, key)
+			}
+		})
+	}
+}
+
+func TestDecodeSDMX(t *testing.T) {
+	tt := []struct {
+		name    string
+		message string
+		valid   bool
+	}{
+		{name: "csv", message: testSDMXCSV, valid: true},
+		{name: "csv empty", message: ""},
+		{name: "csv missing column", message: "KEY,CURRENCY,TIME_PERIOD\nEXR.D.USD.EUR.SP00.A,USD,2022-03-25\n"},
+		{name: "csv no rates", message: "CURRENCY,CURRENCY_DENOM,TIME_PERIOD,OBS_VALUE\n"},
+		{name: "csv invalid rate", message: "CURRENCY,CURRENCY_DENOM,TIME_PERIOD,OBS_VALUE\nUSD,EUR,2022-03-25,abc\n"},
+		{name: "csv invalid date", message: "CURRENCY,CURRENCY_DENOM,TIME_PERIOD,OBS_VALUE\nUSD,EUR,25/03/2022,1.1\n"},
+		{name: "xml", message: testSDMXML, valid: true},
+		{name: "xml not xml", message: "{}"},
+		{name: "xml no rates", message: `<GenericData><DataSet></DataSet></GenericData>`},
+		{name: "xml missing currency", message: `<GenericData><DataSet><Series><Obs><ObsDimension value="2022-03-25"/><ObsValue value="1.1"/></Obs></Series></DataSet></GenericData>`},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			decode := DecodeSDMXCSV
+			if strings.HasPrefix(test.name, "xml") {
+				decode = DecodeSDMXML
+			}
+			var days []DataXML
+			err := decode(strings.NewReader(test.message), func(day DataXML) error {
+				days = append(days, day)
+				return nil
+			})
+			if !test.valid {
+				if _, ok := err.(FeedParseError); !ok {
+					t.Errorf("expecting FeedParseError, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(days) != fmt.Sprint(testSDMXDays) {
+				t.Errorf("expecting %v, got: %v", testSDMXDays, days)
+			}
+		})
+	}
+}
+
+func TestSDMXClient_GetSeries(t *testing.T) {
+	tt := []struct {
+		format SDMXFormat
+		query  SDMXQuery
+		path   string
+		values url.Values
+	}{
+		{
+			format: SDMXFormatCSV,
+			query: SDMXQuery{
+				Currencies: []currency.Currency{currency.USD, currency.CHF},
+				Start:      NewDate(2022, time.March, 24),
+				End:        NewDate(2022, time.March, 25),
+			},
+			path:   "/service/data/EXR/D.USD+CHF.EUR.SP00.A",
+			values: url.Values{"format": {"csvdata"}, "startPeriod": {"2022-03-24"}, "endPeriod": {"2022-03-25"}},
+		},
+		{
+			format: SDMXFormatXML,
+			query:  SDMXQuery{LastN: 2},
+			path:   "/service/data/EXR/D..EUR.SP00.A",
+			values: url.Values{"lastNObservations": {"2"}},
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.format.String(), func(t *testing.T) {
+			var request *http.Request
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				if test.format == SDMXFormatXML {
+					_, _ = w.Write([]byte(testSDMXML))
+					return
+				}
+				_, _ = w.Write([]byte(testSDMXCSV))
+			}))
+			defer ts.Close()
+
+			u, _ := url.Parse(ts.URL)
+			client := NewSDMXClient(u.Scheme, u.Host, NewECBOptions(0, time.Second), log.New()).WithFormat(test.format)
+			data, err := client.GetSeries(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(data.Data) != fmt.Sprint(testSDMXDays) {
+				t.Errorf("expecting %v, got: %v", testSDMXDays, data.Data)
+			}
+			if request.URL.Path != test.path {
+				t.Errorf("expecting path %s, got: %s", test.path, request.URL.Path)
+			}
+			if values := request.URL.Query(); values.Encode() != test.values.Encode() {
+				t.Errorf("expecting query %v, got: %v", test.values, values)
+			}
+			accept := request.Header.Get("Accept")
+			if test.format == SDMXFormatXML && accept != sdmxGenericData {
+				t.Errorf("expecting Accept %s, got: %s", sdmxGenericData, accept)
+			}
+		})
+	}
+}
+
+func TestSDMXClient_feedQuery(t *testing.T) {
+	client := NewSDMXClient("https", SDMXHost, NewECBOptions(0, time.Second), log.New()).WithCurrencies(currency.USD)
+	client.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	tt := []struct {
+		feed   Feed
+		values string
+	}{
+		{feed: FeedDaily, values: "lastNObservations=1"},
+		{feed: Feed90Days, values: "startPeriod=" + NewDate(2022, time.March, 25).AddDays(-feed90DaysSpan).String()},
+		{feed: FeedHistory, values: ""},
+	}
+
+	for _, test := range tt {
+		t.Run(string(test.feed), func(t *testing.T) {
+			query := client.feedQuery(test.feed)
+			if values := query.values().Encode(); values != test.values {
+				t.Errorf("expecting %s, got: %s", test.values, values)
+			}
+			if key := query.Key(); key != "D.USD.EUR.SP00.A" {
+				t.Errorf("expecting D.USD.EUR.SP00.A, got: %s", key)
+			}
+		})
+	}
+}
+
+func TestECBConverter_sdmx(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testSDMXCSV))
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	client := NewSDMXClient(u.Scheme, u.Host, NewECBOptions(0, time.Second), log.New())
+	client.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+	converter := New(client, true, log.New())
+	converter.now = client.now
+
+	value, err := converter.Convert(NewDate(2022, time.March, 24).Time(), 10, currency.CHF, currency.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 10 / 1.0206; value != expected {
+		t.Errorf("expecting %v, got: %v", expected, value)
+	}
+}
+
+func TestSDMXClient_GetFeed_daily(t *testing.T) {
+	// the last observation of discontinued HRK is older than the latest rates
+	message := `KEY,FREQ,CURRENCY,CURRENCY_DENOM,EXR_TYPE,EXR_SUFFIX,TIME_PERIOD,OBS_VALUE,OBS_STATUS
+EXR.D.HRK.EUR.SP00.A,D,HRK,EUR,SP00,A,2022-12-30,7.5365,A
+EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2023-01-02,1.0683,A
+`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(message))
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	client := NewSDMXClient(u.Scheme, u.Host, NewECBOptions(0, time.Second), log.New())
+	data, err := client.GetFeed(FeedDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DataXML{{Date: "2023-01-02", Rates: []RateXML{{Currency: "USD", Rate: 1.0683}}}}
+	if fmt.Sprint(data.Data) != fmt.Sprint(expected) {
+		t.Errorf("expecting %v, got: %v", expected, data.Data)
+	}
+}
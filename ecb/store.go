@@ -0,0 +1,80 @@
+package ecb
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/filiptubic/eurex/currency"
+)
+
+// RateStore is storage for rates fetched from ECB, which backs in-memory cache of ECBConverter. Before fetching rates
+// from ECB, converter loads rates from store, and all newly fetched rates are written to store. Store shared between
+// multiple processes (eg. replicas of a service) ensures that only one of them needs to fetch rates from ECB.
+//
+// Implementations must be safe for concurrent use.
+type RateStore interface {
+	// Get returns rates published on date. When store doesn't hold rates for date, ok is false.
+	Get(date Date) (rates map[currency.Currency]float64, ok bool, err error)
+	// Put stores rates published on date, replacing previously stored ones.
+	Put(date Date, rates map[currency.Currency]float64) error
+	// Dates returns all dates for which store holds rates, in ascending order.
+	Dates() ([]Date, error)
+}
+
+// MemoryStore is RateStore which keeps rates in memory, eg. to share rates between multiple converters of the same process.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	rates map[Date]currencyMap
+}
+
+// NewMemoryStore creates empty MemoryStore object.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rates: make(map[Date]currencyMap)}
+}
+
+// Get returns copy of rates published on date.
+func (s *MemoryStore) Get(date Date) (map[currency.Currency]float64, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rates, ok := s.rates[date]
+	if !ok {
+		return nil, false, nil
+	}
+	return copyRates(rates), true, nil
+}
+
+// Put stores copy of rates published on date.
+func (s *MemoryStore) Put(date Date, rates map[currency.Currency]float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rates[date] = copyRates(rates)
+	return nil
+}
+
+// Dates returns all dates for which store holds rates, in ascending order.
+func (s *MemoryStore) Dates() ([]Date, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dates := make([]Date, 0, len(s.rates))
+	for date := range s.rates {
+		dates = append(dates, date)
+	}
+	sortDates(dates)
+	return dates, nil
+}
+
+// copyRates makes copy of rates for single date.
+func copyRates(rates map[currency.Currency]float64) currencyMap {
+	copied := make(currencyMap, len(rates))
+	for currency, rate := range rates {
+		copied[currency] = rate
+	}
+	return copied
+}
+
+// sortDates sorts dates in ascending order.
+func sortDates(dates []Date) {
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+}
@@ -0,0 +1,138 @@
+package ecb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	log "github.com/sirupsen/logrus"
+)
+
+func testRateStore(t *testing.T, store RateStore) {
+	if _, ok, err := store.Get(NewDate(2022, 3, 25)); ok || err != nil {
+		t.Fatalf("expecting empty store, got ok=%v, err=%v", ok, err)
+	}
+
+	for _, date := range []Date{NewDate(2022, 3, 25), NewDate(2021, 12, 31), NewDate(2022, 3, 24)} {
+		if err := store.Put(date, map[currency.Currency]float64{currency.USD: 1.1, currency.JPY: 130.5}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Put(NewDate(2022, 3, 25), map[currency.Currency]float64{currency.USD: 1.2}); err != nil {
+		t.Fatal(err)
+	}
+
+	rates, ok, err := store.Get(NewDate(2022, 3, 25))
+	if err != nil || !ok {
+		t.Fatalf("expecting stored rates, got ok=%v, err=%v", ok, err)
+	}
+	if len(rates) != 1 || rates[currency.USD] != 1.2 {
+		t.Errorf("expecting replaced rates, got: %v", rates)
+	}
+
+	dates, err := store.Dates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Date{NewDate(2021, 12, 31), NewDate(2022, 3, 24), NewDate(2022, 3, 25)}
+	if len(dates) != len(expected) {
+		t.Fatalf("expecting dates %v, got: %v", expected, dates)
+	}
+	for i := range dates {
+		if dates[i] != expected[i] {
+			t.Errorf("expecting dates %v, got: %v", expected, dates)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testRateStore(t, NewMemoryStore())
+}
+
+func TestMemoryStore_copy(t *testing.T) {
+	store := NewMemoryStore()
+	rates := map[currency.Currency]float64{currency.USD: 1.1}
+	_ = store.Put(NewDate(2022, 3, 25), rates)
+	rates[currency.USD] = 2
+
+	stored, _, _ := store.Get(NewDate(2022, 3, 25))
+	if stored[currency.USD] != 1.1 {
+		t.Errorf("expecting store not to be affected by modification of rates, got: %v", stored[currency.USD])
+	}
+}
+
+func TestECBConverter_withRateStore(t *testing.T) {
+	store := NewMemoryStore()
+	calls := 0
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		calls++
+		return &ECBResponseData{
+			Data: []DataXML{
+				{Date: DateXML("2022-3-24"), Rates: []RateXML{{Currency: "USD", Rate: 2}}},
+				{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 4}}},
+			},
+		}, nil
+	}}
+	now := func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	first := New(client, true, log.New(), WithRateStore(store))
+	first.now = now
+	if _, err := first.Convert(now(), 10, currency.EUR, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	first.Flush()
+	if dates, _ := store.Dates(); len(dates) != 2 {
+		t.Errorf("expecting fetched rates written to store, got: %v", dates)
+	}
+
+	// another converter sharing the same store doesn't need to fetch rates
+	second := New(client, true, log.New(), WithRateStore(store))
+	second.now = now
+	value, err := second.Convert(time.Date(2022, 3, 24, 0, 0, 0, 0, Location), 10, currency.EUR, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 20 {
+		t.Errorf("expecting 20, got: %v", value)
+	}
+	if calls != 1 {
+		t.Errorf("expecting single fetch, got: %d", calls)
+	}
+}
+
+// blockingStore is MemoryStore which writes rates only once released.
+type blockingStore struct {
+	*MemoryStore
+	release chan struct{}
+}
+
+func (s blockingStore) Put(date Date, rates map[currency.Currency]float64) error {
+	<-s.release
+	return s.MemoryStore.Put(date, rates)
+}
+
+func TestECBConverter_withRateStore_background(t *testing.T) {
+	store := blockingStore{MemoryStore: NewMemoryStore(), release: make(chan struct{})}
+	client := &ECBClientMock{GetFeedContextMock: func(ctx context.Context, feed Feed) (*ECBResponseData, error) {
+		return &ECBResponseData{
+			Data: []DataXML{{Date: DateXML("2022-3-25"), Rates: []RateXML{{Currency: "USD", Rate: 4}}}},
+		}, nil
+	}}
+	converter := New(client, true, log.New(), WithRateStore(store))
+	converter.now = func() time.Time { return time.Date(2022, 3, 25, 17, 0, 0, 0, Location) }
+
+	// conversion doesn't wait for store
+	if _, err := converter.Convert(converter.now(), 10, currency.EUR, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	if dates, _ := store.Dates(); len(dates) != 0 {
+		t.Errorf("expecting rates not written yet, got: %v", dates)
+	}
+
+	close(store.release)
+	converter.Flush()
+	if dates, _ := store.Dates(); len(dates) != 1 {
+		t.Errorf("expecting rates written after flush, got: %v", dates)
+	}
+}
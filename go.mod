@@ -3,7 +3,8 @@ module github.com/filiptubic/eurex
 go 1.18
 
 require (
-	github.com/avast/retry-go v3.0.0+incompatible // indirect
-	github.com/sirupsen/logrus v1.8.1 // indirect
-	golang.org/x/sys v0.0.0-20220325203850-36772127a21f // indirect
+	github.com/avast/retry-go v3.0.0+incompatible
+	github.com/sirupsen/logrus v1.8.1
 )
+
+require golang.org/x/sys v0.0.0-20220325203850-36772127a21f // indirect
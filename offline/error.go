@@ -0,0 +1,12 @@
+package offline
+
+import "fmt"
+
+// InvalidSnapshot is used when snapshot of rates has invalid content.
+type InvalidSnapshot struct {
+	msg string
+}
+
+func (e InvalidSnapshot) Error() string {
+	return fmt.Sprintf("invalid snapshot: %s", e.msg)
+}
@@ -0,0 +1,81 @@
+/*
+	This package provides offline converter, which uses snapshot of ECB rates embedded into the binary instead of
+	fetching rates from ECB. Use it in environments which can't reach www.ecb.europa.eu, eg. CI or air-gapped deployments.
+
+	Embedded snapshot contains complete ECB history until the day it was generated, and it is refreshed by invoking
+	"go generate" inside of this package, which downloads the history from ECB. Alternatively, refresh it from already
+	downloaded XML file by invoking in project root:
+		go run ./cmd/eurex-snapshot -in eurofxref-hist.xml -out offline/snapshot.csv.gz
+*/
+package offline
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"io"
+	"math"
+	"time"
+
+	"github.com/filiptubic/eurex/ecb"
+	log "github.com/sirupsen/logrus"
+)
+
+// ProviderName is name of rates provider reported in ecb.ConversionResult by offline converter.
+const ProviderName = "ECB offline snapshot"
+
+//go:generate go run ../cmd/eurex-snapshot -out snapshot.csv.gz
+
+//go:embed snapshot.csv.gz
+var snapshot []byte
+
+// Client implements ecb.ECBClientInterface by serving rates from snapshot, regardless of requested feed.
+type Client struct {
+	data *ecb.ECBResponseData
+}
+
+// NewClient creates Client object serving rates from snapshot, see ReadSnapshot.
+func NewClient(r io.Reader) (*Client, error) {
+	data, err := ReadSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{data: data}, nil
+}
+
+// GetRates returns all rates from snapshot.
+func (c *Client) GetRates() (*ecb.ECBResponseData, error) {
+	return c.data, nil
+}
+
+// GetFeed returns all rates from snapshot.
+func (c *Client) GetFeed(feed ecb.Feed) (*ecb.ECBResponseData, error) {
+	return c.data, nil
+}
+
+// GetFeedContext returns all rates from snapshot, unless ctx is done.
+func (c *Client) GetFeedContext(ctx context.Context, feed ecb.Feed) (*ecb.ECBResponseData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.data, nil
+}
+
+// New creates converter which uses embedded snapshot of ECB rates.
+func New(logger *log.Logger, options ...ecb.Option) (*ecb.ECBConverter, error) {
+	return NewFromSnapshot(bytes.NewReader(snapshot), logger, options...)
+}
+
+// NewFromSnapshot creates converter which uses snapshot of ECB rates read from r, see ReadSnapshot.
+// Snapshot never changes, so its rates are cached forever.
+func NewFromSnapshot(r io.Reader, logger *log.Logger, options ...ecb.Option) (*ecb.ECBConverter, error) {
+	client, err := NewClient(r)
+	if err != nil {
+		return nil, err
+	}
+	options = append([]ecb.Option{
+		ecb.WithCachePolicy(ecb.NewCachePolicy(time.Duration(math.MaxInt64))),
+		ecb.WithProvider(ProviderName),
+	}, options...)
+	return ecb.New(client, true, logger, options...), nil
+}
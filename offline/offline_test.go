@@ -0,0 +1,146 @@
+package offline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/filiptubic/eurex/currency"
+	"github.com/filiptubic/eurex/ecb"
+	log "github.com/sirupsen/logrus"
+)
+
+var testData = &ecb.ECBResponseData{
+	Data: []ecb.DataXML{
+		{
+			Date: "2022-03-25",
+			Rates: []ecb.RateXML{
+				{Currency: "USD", Rate: 1.0983},
+				{Currency: "JPY", Rate: 134.11},
+			},
+		},
+		{
+			Date: "2008-12-30",
+			Rates: []ecb.RateXML{
+				{Currency: "USD", Rate: 1.25},
+				{Currency: "SKK", Rate: 30.5},
+			},
+		},
+	},
+}
+
+func testSnapshot(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	if err := WriteSnapshot(buf, testData); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func ExampleNewFromSnapshot() {
+	buf := &bytes.Buffer{}
+	_ = WriteSnapshot(buf, testData)
+
+	converter, err := NewFromSnapshot(buf, log.New())
+	if err != nil {
+		panic(err)
+	}
+	converted, err := converter.Convert(time.Date(2022, time.March, 25, 0, 0, 0, 0, ecb.Location), 10, currency.EUR, currency.USD)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(converted)
+	// Output: 10.983
+}
+
+func TestReadSnapshot(t *testing.T) {
+	data, err := ReadSnapshot(bytes.NewReader(testSnapshot(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Data) != 2 {
+		t.Fatalf("expecting two dates, got: %d", len(data.Data))
+	}
+	for i, day := range data.Data {
+		expected := testData.Data[i]
+		if day.Date != expected.Date {
+			t.Errorf("expecting date %s, got: %s", expected.Date, day.Date)
+		}
+		if len(day.Rates) != len(expected.Rates) {
+			t.Errorf("expecting rates %v, got: %v", expected.Rates, day.Rates)
+		}
+		for _, rate := range expected.Rates {
+			found := false
+			for _, r := range day.Rates {
+				found = found || r == rate
+			}
+			if !found {
+				t.Errorf("missing rate %v on %s", rate, day.Date)
+			}
+		}
+	}
+}
+
+func TestReadSnapshot_invalid(t *testing.T) {
+	gzipped := func(content string) []byte {
+		buf := &bytes.Buffer{}
+		gz := gzip.NewWriter(buf)
+		_, _ = gz.Write([]byte(content))
+		_ = gz.Close()
+		return buf.Bytes()
+	}
+
+	tt := []struct {
+		name     string
+		snapshot []byte
+	}{
+		{name: "not gzipped", snapshot: []byte("Date,USD\n2022-03-25,1.1\n")},
+		{name: "missing date column", snapshot: gzipped("USD\n1.1\n")},
+		{name: "invalid rate", snapshot: gzipped("Date,USD\n2022-03-25,abc\n")},
+		{name: "invalid record", snapshot: gzipped("Date,USD\n2022-03-25\n")},
+		{name: "no rates", snapshot: gzipped("Date\n")},
+		{name: "no rates on any day", snapshot: gzipped("Date,USD\n2022-03-25,\n2022-03-24,\n")},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ReadSnapshot(bytes.NewReader(test.snapshot)); err == nil {
+				t.Error("expecting error")
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	converter, err := New(log.New())
+	if err != nil {
+		t.Fatalf("expecting embedded snapshot to be valid, regenerate it by invoking go generate: %v", err)
+	}
+
+	// rates published by ECB on 25. March 2022
+	value, err := converter.Convert(time.Date(2022, time.March, 25, 0, 0, 0, 0, ecb.Location), 10, currency.EUR, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 10.983 {
+		t.Errorf("expecting 10.983, got: %v", value)
+	}
+}
+
+func TestNewFromSnapshot(t *testing.T) {
+	converter, err := NewFromSnapshot(bytes.NewReader(testSnapshot(t)), log.New(), ecb.WithLookupPolicy(ecb.LookupPrevious))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// historic currencies are converted using rates from snapshot
+	value, err := converter.Convert(time.Date(2008, time.December, 31, 0, 0, 0, 0, ecb.Location), 10, currency.USD, currency.SKK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 244 {
+		t.Errorf("expecting 244, got: %v", value)
+	}
+}
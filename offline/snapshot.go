@@ -0,0 +1,107 @@
+package offline
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/filiptubic/eurex/ecb"
+)
+
+// dateColumn is name of the first column of snapshot, which holds date of rates.
+const dateColumn = "Date"
+
+// ReadSnapshot reads gzip compressed CSV snapshot of ECB rates. The first column of snapshot holds date in "yyyy-mm-dd"
+// layout and each other column holds rates of one currency, named in header. Empty cell means currency wasn't quoted on date.
+// Days without any rates are skipped, and snapshot without any rates is rejected with InvalidSnapshot, as converter
+// using it would fail every conversion.
+func ReadSnapshot(r io.Reader) (*ecb.ECBResponseData, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	reader := csv.NewReader(gz)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(header) == 0 || header[0] != dateColumn {
+		return nil, InvalidSnapshot{msg: "first column must be " + dateColumn}
+	}
+
+	data := &ecb.ECBResponseData{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		day := ecb.DataXML{Date: ecb.DateXML(record[0])}
+		for i, value := range record[1:] {
+			if value == "" {
+				continue
+			}
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, InvalidSnapshot{msg: "invalid rate " + value + " on " + record[0]}
+			}
+			day.Rates = append(day.Rates, ecb.RateXML{Currency: header[i+1], Rate: rate})
+		}
+		if len(day.Rates) == 0 {
+			// day without rates isn't ECB publication day, so converter must fall back to another day
+			continue
+		}
+		data.Data = append(data.Data, day)
+	}
+	if len(data.Data) == 0 {
+		return nil, InvalidSnapshot{msg: "no rates found"}
+	}
+	return data, nil
+}
+
+// WriteSnapshot writes rates as gzip compressed CSV snapshot, see ReadSnapshot. Currencies are sorted alphabetically,
+// while dates keep order of data.
+func WriteSnapshot(w io.Writer, data *ecb.ECBResponseData) error {
+	columns := make(map[string]int)
+	for _, day := range data.Data {
+		for _, rate := range day.Rates {
+			columns[rate.Currency] = 0
+		}
+	}
+	header := []string{dateColumn}
+	for currency := range columns {
+		header = append(header, currency)
+	}
+	sort.Strings(header[1:])
+	for i, currency := range header {
+		columns[currency] = i
+	}
+
+	gz := gzip.NewWriter(w)
+	writer := csv.NewWriter(gz)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, day := range data.Data {
+		record := make([]string, len(header))
+		record[0] = string(day.Date)
+		for _, rate := range day.Rates {
+			record[columns[rate.Currency]] = strconv.FormatFloat(rate.Rate, 'f', -1, 64)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return gz.Close()
+}